@@ -1,34 +1,146 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"image"
+	"image/png"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"golang.org/x/term"
+
+	"github.com/qraqras/misaki-banner/internal/anim"
 	"github.com/qraqras/misaki-banner/internal/banner"
+	"github.com/qraqras/misaki-banner/internal/clock"
+	mcolor "github.com/qraqras/misaki-banner/internal/color"
 	mfont "github.com/qraqras/misaki-banner/internal/font"
+	"github.com/qraqras/misaki-banner/internal/input"
 )
 
 func main() {
-	shadow := flag.String("shadow", "", "shadow style: outline (box-drawing) or solid (shading)")
+	shadow := flag.String("shadow", "", "shadow style: outline (box-drawing), solid (shading) or glow (border around the text)")
 	fontName := flag.String("font", "misaki_gothic_2nd", "font name: misaki_gothic, misaki_gothic_2nd, or misaki_mincho")
-	color := flag.String("color", "", "text color: preset (c,m,y) or hex (RRGGBB) or RGB (r,g,b)")
+	fontFile := flag.String("font-file", "", "custom font file: TTF, OTF, BDF or PCF (overrides -font)")
+	fallback := flag.String("fallback", "", "comma-separated fallback fonts (font names or files) for missing characters")
+	strict := flag.Bool("strict", false, "fail on invalid options and characters the font cannot render")
+	replacement := flag.Bool("replacement", false, "draw a box for characters no font can render")
+	color := flag.String("color", "", "text color: preset (c,m,y), CSS name, hex (#RGB, RRGGBB), RGB (r,g,b), rgb(), hsl(), oklch() or 256-color index")
 	gradient := flag.Bool("gradient", false, "enable gradient effect (from light to dark, top-left to bottom-right)")
+	gradientColors := flag.String("gradient-colors", "", "gradient color stops, comma-separated (e.g. ff0000,00ff00,0000ff); implies -gradient")
+	gradientDir := flag.String("gradient-dir", "horizontal", "gradient direction: horizontal, vertical, diagonal or radial")
+	shadowDir := flag.String("shadow-dir", "down-right", "shadow direction: down-right, down, down-left, left, up-left, up, up-right or right")
+	shadowDepth := flag.Int("shadow-depth", 1, "shadow depth in dots; more than 1 extrudes the text")
+	shadowColor := flag.String("shadow-color", "", "shadow color, or comma-separated gradient stops (default: text color)")
+	charset := flag.String("charset", "", `custom characters: JSON file, or inline JSON such as '{"text_on":"##"}'`)
+	bg := flag.String("bg", "", "background color, or comma-separated gradient stops following -gradient-dir")
+	invert := flag.Bool("invert", false, "cut the text out of a filled block")
+	colorProfile := flag.String("color-profile", "auto", "terminal color depth: auto, truecolor, 256, 16 or none")
+	palette := flag.String("palette", "", "named gradient palette (see -list-palettes)")
+	paletteFile := flag.String("palette-file", "", "JSON file with extra palettes (default: <config dir>/misaki-banner/palettes.json)")
+	listPalettes := flag.Bool("list-palettes", false, "preview all palettes and exit")
+	compact := flag.String("compact", "", "compact rendering: half (1x2 dots per character), quarter (2x2) or braille (2x4)")
+	vertical := flag.Bool("vertical", false, "lay text out vertically (top-to-bottom, right-to-left)")
+	scale := flag.Int("scale", 1, "magnify every dot N times")
+	scaleX := flag.Int("scale-x", 0, "horizontal magnification (default: -scale)")
+	scaleY := flag.Int("scale-y", 0, "vertical magnification (default: -scale)")
+	smooth := flag.Bool("smooth", false, "smooth diagonals with Scale2x when scaling by multiples of 2")
+	align := flag.String("align", "left", "alignment of multi-line text: left, center or right")
+	margin := flag.Int("margin", 0, "blank columns on the left and lines above and below the banner")
+	padding := flag.Int("padding", 0, "empty dots around every line, shown with -bg or -invert")
+	lineSpacing := flag.Int("line-spacing", 1, "blank lines between lines of text")
+	width := flag.Int("width", 0, "wrap text to N columns (0: terminal width, -1: never wrap)")
+	animate := flag.String("animate", "", "animate on the terminal, or as gif, apng or cast: typewriter, marquee or cycle")
+	fps := flag.Int("fps", 10, "animation frames per second")
+	delay := flag.Duration("delay", 0, "time each animation frame is shown (overrides -fps)")
+	duration := flag.Duration("duration", 0, "how long to loop the animation (0: play once, negative: until interrupted)")
+	altScreen := flag.Bool("alt-screen", false, "play the animation on the alternate screen")
+	file := flag.String("file", "", "read the text from a file instead of the arguments")
+	stream := flag.Bool("stream", false, "render each line of stdin or -file as it arrives")
+	format := flag.String("format", "text", "output format: text, png, svg, gif, apng or cast (asciinema)")
+	output := flag.String("o", "", "output file (default: stdout)")
+	dotSize := flag.Int("dot-size", banner.DefaultDotSize, "image pixels per dot (png, svg, gif and apng only)")
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [options] <text>\n\nOptions:\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] <text | ->\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s clock [options] [format]    (default format: %%H:%%M:%%S)\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s countdown [options] <duration>\n\nOptions:\n", os.Args[0])
 		flag.PrintDefaults()
 	}
-	flag.Parse()
 
-	text := strings.Join(flag.Args(), " ")
-	if text == "" {
-		flag.Usage()
+	// The clock and countdown subcommands take the same options
+	command, args := "", os.Args[1:]
+	if len(args) > 0 && (args[0] == "clock" || args[0] == "countdown") {
+		command, args = args[0], args[1:]
+	}
+	flag.CommandLine.Parse(args)
+
+	if err := loadPalettes(*paletteFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Replace literal \n with newline
-	text = strings.ReplaceAll(text, `\n`, "\n")
+	var text string
+	var next func(now time.Time) (string, bool)
+	switch command {
+	case "clock":
+		layout := strings.Join(flag.Args(), " ")
+		if layout == "" {
+			layout = "%H:%M:%S"
+		}
+		next = func(now time.Time) (string, bool) {
+			return clock.Format(now, layout), false
+		}
+		text, _ = next(time.Now())
+	case "countdown":
+		d, err := time.ParseDuration(flag.Arg(0))
+		if flag.NArg() != 1 || err != nil || d <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid duration: %s (use a positive duration such as 90s, 5m or 1h30m)\n", strings.Join(flag.Args(), " "))
+			os.Exit(1)
+		}
+		deadline := time.Now().Add(d)
+		next = func(now time.Time) (string, bool) {
+			left := deadline.Sub(now)
+			return clock.Countdown(left), left <= 0
+		}
+		text = clock.Countdown(d)
+	default:
+		// Text comes from -file, from stdin given as "-" or piped in,
+		// or from the arguments
+		fromStdin := flag.NArg() == 1 && flag.Arg(0) == "-" ||
+			flag.NArg() == 0 && *file == "" && !*listPalettes && !term.IsTerminal(int(os.Stdin.Fd()))
+		switch {
+		case *file != "" && flag.NArg() > 0:
+			fmt.Fprintf(os.Stderr, "Give the text either as arguments or with -file, not both\n")
+			os.Exit(1)
+		case *stream:
+			if *file == "" && !fromStdin {
+				fmt.Fprintf(os.Stderr, "-stream reads lines from stdin or -file\n")
+				os.Exit(1)
+			}
+		case *file != "" || fromStdin:
+			t, err := input.ReadFile(*file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			text = t
+		default:
+			// Replace literal \n with newline
+			text = strings.ReplaceAll(strings.Join(flag.Args(), " "), `\n`, "\n")
+		}
+	}
+	if text == "" && !*listPalettes && !*stream {
+		flag.Usage()
+		os.Exit(1)
+	}
 
 	var font mfont.FontName
 	switch *fontName {
@@ -43,11 +155,60 @@ func main() {
 		os.Exit(1)
 	}
 
-	face, err := mfont.NewFace(font)
+	var face *mfont.Face
+	var err error
+	if *fontFile != "" {
+		face, err = mfont.LoadFace(*fontFile)
+	} else {
+		face, err = mfont.NewFace(font)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if *fallback != "" {
+		for _, spec := range strings.Split(*fallback, ",") {
+			fb, err := openFallback(strings.TrimSpace(spec))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: fallback %s: %v\n", spec, err)
+				os.Exit(1)
+			}
+			face = face.WithFallback(fb)
+		}
+	}
+	if *replacement {
+		face = face.WithReplacement()
+	}
+
+	var profile mcolor.Profile
+	switch *colorProfile {
+	case "auto":
+		// Recordings are replayed in a browser, not on this terminal
+		if *format == "cast" {
+			profile = mcolor.ProfileTrueColor
+			break
+		}
+		// A file named by -o was asked for explicitly, so only stdout is
+		// checked for a TTY; the file gets what COLORTERM and TERM allow
+		tty := *output != "" || term.IsTerminal(int(os.Stdout.Fd()))
+		profile = mcolor.DetectProfile(os.Getenv, tty)
+	case "truecolor":
+		profile = mcolor.ProfileTrueColor
+	case "256":
+		profile = mcolor.Profile256
+	case "16":
+		profile = mcolor.Profile16
+	case "none":
+		profile = mcolor.ProfileNone
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown color profile: %s (use auto, truecolor, 256, 16 or none)\n", *colorProfile)
+		os.Exit(1)
+	}
+
+	if *listPalettes {
+		printPalettes(face, profile)
+		return
+	}
 
 	var shadowMode banner.ShadowMode
 	switch *shadow {
@@ -55,10 +216,98 @@ func main() {
 		shadowMode = banner.ShadowOutline
 	case "solid":
 		shadowMode = banner.ShadowSolid
+	case "glow":
+		shadowMode = banner.ShadowGlow
 	case "":
 		shadowMode = banner.ShadowNone
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown shadow mode: %s (use outline or solid)\n", *shadow)
+		fmt.Fprintf(os.Stderr, "Unknown shadow mode: %s (use outline, solid or glow)\n", *shadow)
+		os.Exit(1)
+	}
+
+	var shadowDirection banner.ShadowDirection
+	switch *shadowDir {
+	case "down-right":
+		shadowDirection = banner.ShadowDirDownRight
+	case "down", "down-left", "left", "up-left", "up", "up-right", "right":
+		shadowDirection = banner.ShadowDirection(*shadowDir)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown shadow direction: %s (use down-right, down, down-left, left, up-left, up, up-right or right)\n", *shadowDir)
+		os.Exit(1)
+	}
+	if *shadowDepth < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid shadow depth: %d (must be 1 or more)\n", *shadowDepth)
+		os.Exit(1)
+	}
+
+	var charSet *banner.CharSet
+	if *charset != "" {
+		if charSet, err = banner.LoadCharSet(*charset); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *scaleX == 0 {
+		*scaleX = *scale
+	}
+	if *scaleY == 0 {
+		*scaleY = *scale
+	}
+	if *scaleX < 1 || *scaleY < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid scale: %dx%d (must be 1 or more)\n", *scaleX, *scaleY)
+		os.Exit(1)
+	}
+
+	var alignment banner.Alignment
+	switch *align {
+	case "left":
+		alignment = banner.AlignLeft
+	case "center":
+		alignment = banner.AlignCenter
+	case "right":
+		alignment = banner.AlignRight
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown alignment: %s (use left, center or right)\n", *align)
+		os.Exit(1)
+	}
+	if *margin < 0 || *padding < 0 || *lineSpacing < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid spacing: margin, padding and line spacing must be 0 or more\n")
+		os.Exit(1)
+	}
+	// Options treat 0 as the default single blank line
+	spacing := *lineSpacing
+	if spacing == 0 {
+		spacing = -1
+	}
+
+	var compactMode banner.CompactMode
+	switch *compact {
+	case "half":
+		compactMode = banner.CompactHalf
+	case "quarter":
+		compactMode = banner.CompactQuarter
+	case "braille":
+		compactMode = banner.CompactBraille
+	case "":
+		compactMode = banner.CompactNone
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown compact mode: %s (use half, quarter or braille)\n", *compact)
+		os.Exit(1)
+	}
+
+	var gradientDirection banner.GradientDirection
+	switch *gradientDir {
+	case "horizontal":
+		gradientDirection = banner.GradientHorizontal
+	case "vertical":
+		gradientDirection = banner.GradientVertical
+	case "diagonal":
+		gradientDirection = banner.GradientDiagonal
+	case "radial":
+		gradientDirection = banner.GradientRadial
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown gradient direction: %s (use horizontal, vertical, diagonal or radial)\n", *gradientDir)
 		os.Exit(1)
 	}
 
@@ -66,8 +315,302 @@ func main() {
 		Shadow:   shadowMode,
 		Color:    *color,
 		Gradient: *gradient,
+		Vertical: *vertical,
+		Compact:  compactMode,
+
+		GradientColors: *gradientColors,
+		GradientDir:    gradientDirection,
+		Palette:        *palette,
+		Background:     *bg,
+		ShadowDir:      shadowDirection,
+		ShadowDepth:    *shadowDepth,
+		ShadowColor:    *shadowColor,
+		Invert:         *invert,
+		CharSet:        charSet,
+		ScaleX:         *scaleX,
+		ScaleY:         *scaleY,
+		Smooth:         *smooth,
+		Width:          wrapWidth(*width, *format, *output),
+		Align:          alignment,
+		Margin:         *margin,
+		Padding:        *padding,
+		LineSpacing:    spacing,
+		ColorProfile:   profile,
+	}
+
+	check(face, text, opts, *strict)
+
+	var animation banner.Animation
+	switch *animate {
+	case "typewriter", "marquee", "cycle":
+		animation = banner.Animation(*animate)
+	case "":
+		animation = banner.AnimNone
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown animation: %s (use typewriter, marquee or cycle)\n", *animate)
+		os.Exit(1)
+	}
+	if *stream {
+		if *format != "text" || animation != banner.AnimNone || next != nil {
+			fmt.Fprintf(os.Stderr, "-stream writes text and cannot be combined with -animate, images or subcommands\n")
+			os.Exit(1)
+		}
+		in := os.Stdin
+		if *file != "" {
+			if in, err = os.Open(*file); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			defer in.Close()
+		}
+		err := writeOutput(*output, func(w io.Writer) error {
+			return input.StreamLines(in, w, func(line string) string {
+				// The options were checked above; only the glyphs are left
+				check(face, line, banner.Options{}, *strict)
+				return banner.Generate(face, line, opts) + strings.Repeat("\n", *lineSpacing)
+			})
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	result := banner.Generate(face, text, opts)
-	fmt.Println(result)
+	if *fps < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid frame rate: %d (must be 1 or more)\n", *fps)
+		os.Exit(1)
+	}
+	if *delay <= 0 {
+		*delay = time.Second / time.Duration(*fps)
+	}
+	if next != nil {
+		if *format != "text" || *output != "" || animation != banner.AnimNone {
+			fmt.Fprintf(os.Stderr, "%s redraws on the terminal and needs text output to stdout without -animate\n", command)
+			os.Exit(1)
+		}
+		if err := live(face, opts, next, *altScreen); err != nil {
+			if errors.Is(err, context.Canceled) {
+				os.Exit(130)
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if animation != banner.AnimNone && *format == "text" {
+		if *output != "" {
+			fmt.Fprintf(os.Stderr, "Text animation is played on the terminal and cannot be written to a file (use -format gif, apng or cast)\n")
+			os.Exit(1)
+		}
+		if err := play(face, text, opts, animation, *delay, *duration, *altScreen); err != nil {
+			if errors.Is(err, context.Canceled) {
+				os.Exit(130)
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if animation != banner.AnimNone && *format != "gif" && *format != "apng" && *format != "cast" {
+		fmt.Fprintf(os.Stderr, "Animation needs -format text, gif, apng or cast\n")
+		os.Exit(1)
+	}
+
+	var write func(w io.Writer) error
+	switch *format {
+	case "text":
+		write = func(w io.Writer) error {
+			_, err := fmt.Fprintln(w, banner.Generate(face, text, opts))
+			return err
+		}
+	case "cast":
+		frames := textFrames(face, text, opts, animation)
+		write = func(w io.Writer) error {
+			return anim.EncodeCast(w, frames, *delay, *duration, *altScreen)
+		}
+	case "png", "svg", "gif", "apng":
+		if *dotSize < 1 {
+			fmt.Fprintf(os.Stderr, "Invalid dot size: %d (must be 1 or more)\n", *dotSize)
+			os.Exit(1)
+		}
+		switch *format {
+		case "png":
+			write = func(w io.Writer) error {
+				return png.Encode(w, banner.GenerateImage(face, text, opts, *dotSize))
+			}
+		case "svg":
+			write = func(w io.Writer) error {
+				_, err := io.WriteString(w, banner.GenerateSVG(face, text, opts, *dotSize))
+				return err
+			}
+		default:
+			var frames []*image.NRGBA
+			for _, o := range banner.Frames(face, text, opts, animation) {
+				frames = append(frames, banner.GenerateImage(face, text, o, *dotSize))
+			}
+			encode := anim.EncodeGIF
+			if *format == "apng" {
+				encode = anim.EncodeAPNG
+			}
+			write = func(w io.Writer) error {
+				return encode(w, frames, *delay)
+			}
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format: %s (use text, png, svg, gif, apng or cast)\n", *format)
+		os.Exit(1)
+	}
+
+	if err := writeOutput(*output, write); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// play renders the frames of the animation and plays them on stdout until
+// they finish or the program is interrupted, restoring the terminal either way.
+func play(face *mfont.Face, text string, opts banner.Options, animation banner.Animation, delay, duration time.Duration, alt bool) error {
+	frames := textFrames(face, text, opts, animation)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	screen := anim.NewScreen(os.Stdout, alt)
+	if err := screen.Start(); err != nil {
+		return err
+	}
+	err := anim.Play(ctx, screen, frames, delay, duration)
+	if stopErr := screen.Stop(); err == nil {
+		err = stopErr
+	}
+	return err
+}
+
+// check reports the problems banner.Check finds in text and opts as
+// warnings, or as errors that exit in strict mode.
+func check(face *mfont.Face, text string, opts banner.Options, strict bool) {
+	err := banner.Check(face, text, opts)
+	if err == nil {
+		return
+	}
+	prefix := "Warning"
+	if strict {
+		prefix = "Error"
+	}
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", prefix, line)
+	}
+	if strict {
+		os.Exit(1)
+	}
+}
+
+// live redraws the banner of the text returned by next every second, on
+// the second, until next reports done or the program is interrupted.
+func live(face *mfont.Face, opts banner.Options, next func(now time.Time) (string, bool), alt bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	screen := anim.NewScreen(os.Stdout, alt)
+	if err := screen.Start(); err != nil {
+		return err
+	}
+	render := func(now time.Time) (string, bool) {
+		text, done := next(now)
+		return banner.Generate(face, text, opts), done
+	}
+	err := anim.Live(ctx, screen, time.Now().Truncate(time.Second), time.Second, render)
+	if stopErr := screen.Stop(); err == nil {
+		err = stopErr
+	}
+	return err
+}
+
+// textFrames renders every frame of the animation as text.
+func textFrames(face *mfont.Face, text string, opts banner.Options, animation banner.Animation) []string {
+	var frames []string
+	for _, o := range banner.Frames(face, text, opts, animation) {
+		frames = append(frames, banner.Generate(face, text, o))
+	}
+	return frames
+}
+
+// loadPalettes registers the palettes in path. If path is empty, the
+// palettes.json file in the user config directory is loaded if it exists.
+func loadPalettes(path string) error {
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(dir, "misaki-banner", "palettes.json")
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := mcolor.LoadPalettes(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// printPalettes previews every palette by rendering its name in its colors.
+func printPalettes(face *mfont.Face, profile mcolor.Profile) {
+	for _, name := range mcolor.PaletteNames() {
+		opts := banner.Options{Palette: name, Compact: banner.CompactHalf, ColorProfile: profile}
+		fmt.Println(name)
+		fmt.Println(banner.Generate(face, name, opts))
+		fmt.Println()
+	}
+}
+
+// openFallback opens a fallback face given either an embedded font name or a font file path.
+func openFallback(spec string) (*mfont.Face, error) {
+	if face, err := mfont.NewFace(mfont.FontName(spec)); err == nil {
+		return face, nil
+	}
+	return mfont.LoadFace(spec)
+}
+
+// wrapWidth returns the column count to wrap text to. A zero width means
+// the terminal width when printing text to a terminal, and otherwise
+// COLUMNS if set; a negative width or image output disables wrapping.
+func wrapWidth(width int, format, output string) int {
+	if width != 0 {
+		return max(width, 0)
+	}
+	if format != "text" {
+		return 0
+	}
+	if output == "" && term.IsTerminal(int(os.Stdout.Fd())) {
+		if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			return w
+		}
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 0
+}
+
+// writeOutput calls write with the file at path, or with stdout if path is empty.
+func writeOutput(path string, write func(w io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
@@ -4,4 +4,9 @@ go 1.26.0
 
 require golang.org/x/image v0.36.0
 
-require golang.org/x/text v0.34.0 // indirect
+require (
+	golang.org/x/term v0.46.0
+	golang.org/x/text v0.34.0
+)
+
+require golang.org/x/sys v0.48.0 // indirect
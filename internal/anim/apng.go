@@ -0,0 +1,107 @@
+package anim
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"io"
+	"time"
+)
+
+// pngSignature starts every PNG file.
+const pngSignature = "\x89PNG\r\n\x1a\n"
+
+// APNG frame control values.
+const (
+	apngDisposeBackground = 1 // clear the frame area before the next frame
+	apngBlendSource       = 0 // replace the frame area, alpha included
+)
+
+// EncodeAPNG writes frames as an animated PNG that loops forever, showing
+// each frame for delay. Frames are placed at the top-left of a canvas large
+// enough for all of them and keep their full alpha. Viewers without APNG
+// support show the first frame.
+func EncodeAPNG(w io.Writer, frames []*image.NRGBA, delay time.Duration) error {
+	bounds := canvas(frames)
+	width, height := uint32(bounds.Dx()), uint32(bounds.Dy())
+	e := &apngEncoder{w: w}
+
+	e.write(pngSignature)
+	e.chunk("IHDR", be32(width), be32(height), []byte{8, 6, 0, 0, 0}) // 8-bit RGBA
+	e.chunk("acTL", be32(uint32(len(frames))), be32(0))               // loop forever
+
+	ms := min(delay.Milliseconds(), 0xffff)
+	var seq uint32
+	for i, frame := range frames {
+		e.chunk("fcTL", be32(seq), be32(width), be32(height), be32(0), be32(0),
+			be16(uint16(ms)), be16(1000), []byte{apngDisposeBackground, apngBlendSource})
+		seq++
+
+		data, err := compressFrame(frame, bounds)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			e.chunk("IDAT", data)
+		} else {
+			e.chunk("fdAT", be32(seq), data)
+			seq++
+		}
+	}
+	e.chunk("IEND")
+	return e.err
+}
+
+// apngEncoder writes PNG chunks, keeping the first error.
+type apngEncoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *apngEncoder) write(s string) {
+	if e.err == nil {
+		_, e.err = io.WriteString(e.w, s)
+	}
+}
+
+// chunk writes a chunk of the given type whose data is the concatenation
+// of parts, followed by its CRC.
+func (e *apngEncoder) chunk(typ string, parts ...[]byte) {
+	data := bytes.Join(parts, nil)
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(typ))
+	crc.Write(data)
+	e.write(string(be32(uint32(len(data)))) + typ + string(data) + string(be32(crc.Sum32())))
+}
+
+// compressFrame returns the zlib-compressed RGBA scanlines of frame drawn
+// at the top-left of bounds, each preceded by filter type 0 (none).
+func compressFrame(frame *image.NRGBA, bounds image.Rectangle) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	row := make([]byte, 1+4*bounds.Dx())
+	for y := 0; y < bounds.Dy(); y++ {
+		clear(row)
+		if y < frame.Rect.Dy() {
+			off := frame.PixOffset(frame.Rect.Min.X, frame.Rect.Min.Y+y)
+			copy(row[1:], frame.Pix[off:off+4*frame.Rect.Dx()])
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func be32(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
+
+func be16(v uint16) []byte {
+	return binary.BigEndian.AppendUint16(nil, v)
+}
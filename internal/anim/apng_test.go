@@ -0,0 +1,62 @@
+package anim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image/color"
+	"image/png"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEncodeAPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeAPNG(&buf, testFrames(), 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	// Walk the chunks, checking every CRC
+	data := buf.Bytes()[len(pngSignature):]
+	var types []string
+	var seqs []uint32
+	for len(data) > 0 {
+		n := binary.BigEndian.Uint32(data)
+		typ, body := string(data[4:8]), data[8:8+n]
+		if crc := binary.BigEndian.Uint32(data[8+n:]); crc != crc32.ChecksumIEEE(data[4:8+n]) {
+			t.Errorf("%s chunk has a bad CRC", typ)
+		}
+		types = append(types, typ)
+		switch typ {
+		case "acTL":
+			if frames := binary.BigEndian.Uint32(body); frames != 2 {
+				t.Errorf("acTL has %d frames, want 2", frames)
+			}
+		case "fcTL":
+			seqs = append(seqs, binary.BigEndian.Uint32(body))
+			if delay := binary.BigEndian.Uint16(body[20:]); delay != 100 {
+				t.Errorf("fcTL delay = %d, want 100ms", delay)
+			}
+		case "fdAT":
+			seqs = append(seqs, binary.BigEndian.Uint32(body))
+		}
+		data = data[12+n:]
+	}
+	wantTypes := []string{"IHDR", "acTL", "fcTL", "IDAT", "fcTL", "fdAT", "IEND"}
+	if !slices.Equal(types, wantTypes) {
+		t.Errorf("chunks = %v, want %v", types, wantTypes)
+	}
+	if !slices.Equal(seqs, []uint32{0, 1, 2}) {
+		t.Errorf("sequence numbers = %v, want 0, 1, 2", seqs)
+	}
+
+	// Decoders without APNG support see the first frame
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := color.NRGBAModel.Convert(img.At(0, 0)); got != (color.NRGBA{R: 255, A: 255}) {
+		t.Errorf("first frame (0, 0) = %v, want red", got)
+	}
+}
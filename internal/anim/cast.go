@@ -0,0 +1,65 @@
+package anim
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/qraqras/misaki-banner/internal/banner"
+)
+
+// castHeader is the first line of an asciinema v2 recording.
+type castHeader struct {
+	Version int `json:"version"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+}
+
+// EncodeCast writes frames as an asciinema v2 recording in which each frame
+// is drawn in place like Play does, delay apart. A single frame makes a
+// still recording. A positive duration loops the frames until it has
+// passed; otherwise they are recorded once. The terminal size fits the
+// largest frame and the line the cursor ends on.
+func EncodeCast(w io.Writer, frames []string, delay, duration time.Duration, alt bool) error {
+	width, height := 1, 1
+	for _, frame := range frames {
+		lines := strings.Split(frame, "\n")
+		height = max(height, len(lines)+1)
+		for _, line := range lines {
+			width = max(width, banner.VisibleWidth(line))
+		}
+	}
+
+	n := len(frames)
+	if duration > 0 && delay > 0 {
+		n = max(n, int(duration/delay))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(castHeader{Version: 2, Width: width, Height: height}); err != nil {
+		return err
+	}
+
+	// The screen writes into buf, which is flushed as one event per frame
+	var buf bytes.Buffer
+	screen := NewScreen(&buf, alt)
+	event := func(i int) error {
+		t := (time.Duration(i) * delay).Seconds()
+		err := enc.Encode([]any{t, "o", buf.String()})
+		buf.Reset()
+		return err
+	}
+
+	screen.Start()
+	for i := 0; i < n && len(frames) > 0; i++ {
+		screen.Draw(frames[i%len(frames)])
+		if err := event(i); err != nil {
+			return err
+		}
+	}
+	screen.Stop()
+	return event(n)
+}
@@ -0,0 +1,71 @@
+package anim
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readCast decodes the header and events of a recording.
+func readCast(t *testing.T, s string) (castHeader, [][]any) {
+	t.Helper()
+	sc := bufio.NewScanner(strings.NewReader(s))
+	var header castHeader
+	var events [][]any
+	for i := 0; sc.Scan(); i++ {
+		if i == 0 {
+			if err := json.Unmarshal(sc.Bytes(), &header); err != nil {
+				t.Fatalf("header: %v", err)
+			}
+			continue
+		}
+		var ev []any
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+		events = append(events, ev)
+	}
+	return header, events
+}
+
+func TestEncodeCast_Still(t *testing.T) {
+	var b strings.Builder
+	frame := "\033[38;2;0;255;255m██\033[0m  \n██"
+	if err := EncodeCast(&b, []string{frame}, 100*time.Millisecond, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	header, events := readCast(t, b.String())
+	if header != (castHeader{Version: 2, Width: 4, Height: 3}) {
+		t.Errorf("header = %+v, want version 2, 4x3", header)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want the frame and the cursor restore", len(events))
+	}
+	if data := events[0][2].(string); !strings.Contains(data, "\033[38;2;0;255;255m██") || !strings.Contains(data, "\r\n") {
+		t.Errorf("first event %q should keep the colors and use CRLF", data)
+	}
+	if events[0][1] != "o" {
+		t.Errorf("event type = %v, want output", events[0][1])
+	}
+}
+
+func TestEncodeCast_Animated(t *testing.T) {
+	var b strings.Builder
+	if err := EncodeCast(&b, []string{"a", "b"}, 250*time.Millisecond, time.Second, true); err != nil {
+		t.Fatal(err)
+	}
+	_, events := readCast(t, b.String())
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 4 looped frames and the restore", len(events))
+	}
+	for i, ev := range events {
+		if want := float64(i) * 0.25; ev[0] != want {
+			t.Errorf("event %d at %v, want %v", i, ev[0], want)
+		}
+	}
+	if data := events[2][2].(string); !strings.HasPrefix(data, cursorHome+"a") {
+		t.Errorf("event 2 = %q, want the first frame again", data)
+	}
+}
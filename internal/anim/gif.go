@@ -0,0 +1,101 @@
+package anim
+
+import (
+	"cmp"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"maps"
+	"slices"
+	"time"
+)
+
+// gifMatte is the color translucent dots are blended onto. GIF pixels are
+// either opaque or fully transparent, so shadows drawn at half opacity
+// would otherwise be lost.
+var gifMatte = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+
+// EncodeGIF writes frames as a GIF that loops forever, showing each frame
+// for delay. Frames are placed at the top-left of a canvas large enough
+// for all of them. All frames share one palette of the most frequent
+// colors, with index 0 transparent.
+func EncodeGIF(w io.Writer, frames []*image.NRGBA, delay time.Duration) error {
+	bounds := canvas(frames)
+	pal := gifPalette(frames)
+	index := make(map[color.NRGBA]uint8, len(pal))
+
+	anim := &gif.GIF{Config: image.Config{ColorModel: pal, Width: bounds.Dx(), Height: bounds.Dy()}}
+	for _, frame := range frames {
+		img := image.NewPaletted(bounds, pal)
+		for y := frame.Rect.Min.Y; y < frame.Rect.Max.Y; y++ {
+			for x := frame.Rect.Min.X; x < frame.Rect.Max.X; x++ {
+				c := flatten(frame.NRGBAAt(x, y))
+				if c.A == 0 {
+					continue
+				}
+				i, ok := index[c]
+				if !ok {
+					i = uint8(pal.Index(c))
+					index[c] = i
+				}
+				img.SetColorIndex(x-frame.Rect.Min.X, y-frame.Rect.Min.Y, i)
+			}
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+		anim.Disposal = append(anim.Disposal, gif.DisposalBackground)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// gifPalette returns a transparent entry followed by the up to 255 most
+// frequent flattened colors of frames. Any other color is drawn with the
+// nearest palette entry.
+func gifPalette(frames []*image.NRGBA) color.Palette {
+	counts := make(map[color.NRGBA]int)
+	for _, frame := range frames {
+		for y := frame.Rect.Min.Y; y < frame.Rect.Max.Y; y++ {
+			for x := frame.Rect.Min.X; x < frame.Rect.Max.X; x++ {
+				if c := flatten(frame.NRGBAAt(x, y)); c.A != 0 {
+					counts[c]++
+				}
+			}
+		}
+	}
+	colors := slices.SortedFunc(maps.Keys(counts), func(a, b color.NRGBA) int {
+		if n := cmp.Compare(counts[b], counts[a]); n != 0 {
+			return n
+		}
+		// Break ties by value so the palette does not depend on map order
+		return cmp.Compare(uint32(a.R)<<16|uint32(a.G)<<8|uint32(a.B), uint32(b.R)<<16|uint32(b.G)<<8|uint32(b.B))
+	})
+
+	pal := color.Palette{color.NRGBA{}}
+	for _, c := range colors[:min(len(colors), 255)] {
+		pal = append(pal, c)
+	}
+	return pal
+}
+
+// flatten blends a translucent pixel onto gifMatte. Transparent pixels
+// stay transparent.
+func flatten(c color.NRGBA) color.NRGBA {
+	if c.A == 0 || c.A == 255 {
+		return c
+	}
+	blend := func(v, m uint8) uint8 {
+		return uint8((int(v)*int(c.A) + int(m)*(255-int(c.A)) + 127) / 255)
+	}
+	return color.NRGBA{R: blend(c.R, gifMatte.R), G: blend(c.G, gifMatte.G), B: blend(c.B, gifMatte.B), A: 255}
+}
+
+// canvas returns the bounds, at the origin, of a canvas large enough for
+// every frame.
+func canvas(frames []*image.NRGBA) image.Rectangle {
+	var w, h int
+	for _, frame := range frames {
+		w, h = max(w, frame.Rect.Dx()), max(h, frame.Rect.Dy())
+	}
+	return image.Rect(0, 0, max(w, 1), max(h, 1))
+}
@@ -0,0 +1,62 @@
+package anim
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+// testFrames returns two 2×1 frames: a red dot that moves from left to
+// right, and a half-transparent blue dot in the second frame.
+func testFrames() []*image.NRGBA {
+	a := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	a.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+	b := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	b.SetNRGBA(1, 0, color.NRGBA{R: 255, A: 255})
+	b.SetNRGBA(0, 0, color.NRGBA{B: 255, A: 128})
+	return []*image.NRGBA{a, b}
+}
+
+func TestEncodeGIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, testFrames(), 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != 2 || g.Delay[0] != 10 || g.LoopCount != 0 {
+		t.Fatalf("got %d frames with delay %v and loop count %d, want 2 looping frames of 10", len(g.Image), g.Delay, g.LoopCount)
+	}
+
+	red := color.RGBA{R: 255, A: 255}
+	if got := g.Image[0].At(0, 0); got != red {
+		t.Errorf("frame 0 (0, 0) = %v, want red", got)
+	}
+	if _, _, _, a := g.Image[0].At(1, 0).RGBA(); a != 0 {
+		t.Error("frame 0 (1, 0) should be transparent")
+	}
+	// Half-transparent blue is blended onto white
+	if got := g.Image[1].At(0, 0); got != (color.RGBA{R: 127, G: 127, B: 255, A: 255}) {
+		t.Errorf("frame 1 (0, 0) = %v, want light blue", got)
+	}
+}
+
+func TestGIFPalette_Limit(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 300, 1))
+	for x := 0; x < 300; x++ {
+		img.SetNRGBA(x, 0, color.NRGBA{R: uint8(x), G: uint8(x / 2), A: 255})
+	}
+	if pal := gifPalette([]*image.NRGBA{img}); len(pal) != 256 {
+		t.Errorf("palette has %d colors, want 256", len(pal))
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, []*image.NRGBA{img}, 0); err != nil {
+		t.Errorf("EncodeGIF with more than 255 colors: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+// Package anim plays banner frames on a terminal.
+package anim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Terminal control sequences.
+const (
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	enterAltScreen = "\033[?1049h"
+	leaveAltScreen = "\033[?1049l"
+	cursorHome     = "\033[H"
+	clearLine      = "\033[K" // erase to the end of the line
+	clearBelow     = "\033[J" // erase to the end of the screen
+	resetStyle     = "\033[0m"
+)
+
+// Screen redraws frames in place on a terminal. Each frame is written with
+// a single Write and overwrites the previous one line by line, so nothing
+// flickers. Call Start before the first frame and Stop to restore the
+// terminal.
+type Screen struct {
+	w     io.Writer
+	alt   bool
+	lines int // lines of the last frame, for moving back over it
+}
+
+// NewScreen returns a Screen writing to w. With alt set, frames are drawn
+// on the alternate screen, which is left on Stop so the terminal contents
+// come back; otherwise the last frame stays below the prompt.
+func NewScreen(w io.Writer, alt bool) *Screen {
+	return &Screen{w: w, alt: alt}
+}
+
+// Start hides the cursor and enters the alternate screen if enabled.
+func (s *Screen) Start() error {
+	seq := hideCursor
+	if s.alt {
+		seq = enterAltScreen + hideCursor
+	}
+	_, err := io.WriteString(s.w, seq)
+	return err
+}
+
+// Draw replaces the previous frame with frame.
+func (s *Screen) Draw(frame string) error {
+	var b strings.Builder
+	switch {
+	case s.alt:
+		b.WriteString(cursorHome)
+	case s.lines > 1:
+		fmt.Fprintf(&b, "\r\033[%dA", s.lines-1)
+	case s.lines == 1:
+		b.WriteString("\r")
+	}
+	lines := strings.Split(frame, "\n")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString(clearLine)
+	}
+	b.WriteString(clearBelow)
+	s.lines = len(lines)
+
+	_, err := io.WriteString(s.w, b.String())
+	return err
+}
+
+// Stop resets the colors, shows the cursor and leaves the alternate screen.
+// Without the alternate screen the cursor moves below the last frame.
+func (s *Screen) Stop() error {
+	seq := resetStyle + showCursor
+	if s.alt {
+		seq += leaveAltScreen
+	} else if s.lines > 0 {
+		seq += "\r\n"
+	}
+	_, err := io.WriteString(s.w, seq)
+	return err
+}
+
+// Play draws frames on s, each shown for delay, until ctx is done. A
+// positive duration loops the frames until it has passed, a negative one
+// loops them until ctx is done, and zero plays them once. The last frame
+// drawn stays on the screen. Play returns ctx.Err() if it was interrupted.
+func Play(ctx context.Context, s *Screen, frames []string, delay, duration time.Duration) error {
+	if len(frames) == 0 {
+		return nil
+	}
+	ticker := time.NewTicker(max(delay, time.Millisecond))
+	defer ticker.Stop()
+
+	var deadline <-chan time.Time
+	if duration > 0 {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	for i := 0; ; i++ {
+		if i == len(frames) {
+			if duration == 0 {
+				return nil
+			}
+			i = 0
+		}
+		if err := s.Draw(frames[i]); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Live draws the frame returned by next right away and again every
+// interval, on the ticks origin+k*interval, until next reports done or ctx
+// is done. Frames equal to the last one drawn are skipped. Live returns
+// ctx.Err() if it was interrupted.
+func Live(ctx context.Context, s *Screen, origin time.Time, interval time.Duration, next func(now time.Time) (frame string, done bool)) error {
+	last := ""
+	for {
+		now := time.Now()
+		frame, done := next(now)
+		if frame != last {
+			if err := s.Draw(frame); err != nil {
+				return err
+			}
+			last = frame
+		}
+		if done {
+			return nil
+		}
+
+		wait := interval - now.Sub(origin)%interval
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
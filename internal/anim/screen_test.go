@@ -0,0 +1,103 @@
+package anim
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScreen_Draw(t *testing.T) {
+	var b strings.Builder
+	s := NewScreen(&b, false)
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	s.Draw("ab\ncd")
+	s.Draw("ef")
+	s.Stop()
+
+	want := hideCursor +
+		"ab" + clearLine + "\r\ncd" + clearLine + clearBelow +
+		"\r\033[1A" + "ef" + clearLine + clearBelow +
+		resetStyle + showCursor + "\r\n"
+	if b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestScreen_AltScreen(t *testing.T) {
+	var b strings.Builder
+	s := NewScreen(&b, true)
+	s.Start()
+	s.Draw("ab")
+	s.Stop()
+
+	want := enterAltScreen + hideCursor +
+		cursorHome + "ab" + clearLine + clearBelow +
+		resetStyle + showCursor + leaveAltScreen
+	if b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestPlay_Once(t *testing.T) {
+	var b strings.Builder
+	err := Play(context.Background(), NewScreen(&b, true), []string{"1", "2", "3"}, time.Millisecond, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(b.String(), cursorHome); got != 3 {
+		t.Errorf("drew %d frames, want 3", got)
+	}
+}
+
+func TestPlay_Duration(t *testing.T) {
+	var b strings.Builder
+	err := Play(context.Background(), NewScreen(&b, true), []string{"1", "2"}, time.Millisecond, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(b.String(), cursorHome); got <= 2 {
+		t.Errorf("drew %d frames, want the frames to loop", got)
+	}
+}
+
+func TestPlay_Interrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var b strings.Builder
+	err := Play(ctx, NewScreen(&b, true), []string{"1", "2"}, 100*time.Millisecond, -1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Play() = %v, want context.Canceled", err)
+	}
+}
+
+func TestLive(t *testing.T) {
+	var b strings.Builder
+	calls := 0
+	next := func(now time.Time) (string, bool) {
+		calls++
+		// The second frame repeats the first and is not redrawn
+		frames := []string{"3", "3", "2", "1"}
+		return frames[calls-1], calls == len(frames)
+	}
+	err := Live(context.Background(), NewScreen(&b, true), time.Now(), time.Millisecond, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(b.String(), cursorHome); got != 3 {
+		t.Errorf("drew %d frames, want 3", got)
+	}
+}
+
+func TestLive_Interrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var b strings.Builder
+	err := Live(ctx, NewScreen(&b, true), time.Now(), time.Hour, func(time.Time) (string, bool) { return "x", false })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Live() = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,140 @@
+package banner
+
+import (
+	mfont "github.com/qraqras/misaki-banner/internal/font"
+)
+
+// Animation selects how Frames animates a banner.
+type Animation string
+
+const (
+	AnimNone       Animation = ""           // a single still frame
+	AnimTypewriter Animation = "typewriter" // reveal one character per frame
+	AnimMarquee    Animation = "marquee"    // scroll the text from right to left
+	AnimCycle      Animation = "cycle"      // shift the gradient colors along the text
+)
+
+// cycleFrames is the number of frames of one AnimCycle loop.
+const cycleFrames = 30
+
+// cycleColors are the gradient stops AnimCycle uses when opts has no color.
+const cycleColors = "red,yellow,lime,cyan,blue,magenta"
+
+// Frames returns the options of every frame of the animation of text, to be
+// rendered in turn with Generate, GenerateImage or GenerateSVG.
+// A marquee scrolls through a window opts.Width columns wide, or as wide as
+// the text if Width is 0. A color cycle enables the gradient of opts, and
+// cycles through the rainbow if opts has no color.
+func Frames(face *mfont.Face, text string, opts Options, anim Animation) []Options {
+	var frames []Options
+	switch anim {
+	case AnimTypewriter:
+		n := 0
+		for _, line := range textLines(face, text, opts) {
+			n += len([]rune(line))
+		}
+		for i := 1; i <= n; i++ {
+			opts.Reveal = i
+			frames = append(frames, opts)
+		}
+	case AnimMarquee:
+		viewport := 0
+		if opts.Width > 0 {
+			viewport = opts.fitDots()
+		}
+		// Any window disables wrapping; the real size is set below
+		opts.Viewport = 1
+		width := 0
+		for _, grid := range glyphGrids(face, textLines(face, text, opts), opts) {
+			width = max(width, len(grid[0]))
+		}
+		if viewport == 0 {
+			viewport = width
+		}
+		opts.Viewport = viewport
+		for i := 0; i < width+viewport; i++ {
+			opts.Scroll = i
+			frames = append(frames, opts)
+		}
+	case AnimCycle:
+		if opts.Palette == "" && opts.GradientColors == "" {
+			if opts.Color == "" {
+				opts.GradientColors = cycleColors
+			} else {
+				opts.Gradient = true
+			}
+		}
+		for i := 1; i <= cycleFrames; i++ {
+			opts.Phase = float64(i) / cycleFrames
+			frames = append(frames, opts)
+		}
+	}
+	if len(frames) == 0 {
+		frames = []Options{opts}
+	}
+	return frames
+}
+
+// keepsBlankRows reports whether o is a typewriter or marquee frame, whose
+// blank rows are kept so every frame has the same height and the text does
+// not jump as glyphs come and go.
+func (o Options) keepsBlankRows() bool {
+	return o.Reveal > 0 || o.Viewport > 0
+}
+
+// revealCounts returns how many runes of each line are shown when only the
+// first n runes of all lines are. n <= 0 shows every rune.
+func revealCounts(lines []string, n int) []int {
+	counts := make([]int, len(lines))
+	left := n
+	for i, line := range lines {
+		counts[i] = len([]rune(line))
+		if n > 0 {
+			counts[i] = min(counts[i], left)
+			left -= counts[i]
+		}
+	}
+	return counts
+}
+
+// runesWidth returns the width in dots of runes laid out by buildGrid.
+func runesWidth(face *mfont.Face, runes []rune) int {
+	w := 0
+	for _, r := range runes {
+		if bm := face.RuneBitmap(r); len(bm) > 0 {
+			w += len(bm[0])
+		}
+	}
+	return w
+}
+
+// hideColumns clears every dot of grid from column x onwards.
+func hideColumns(grid [][]bool, x int) {
+	for _, row := range grid {
+		clear(row[min(x, len(row)):])
+	}
+}
+
+// scrollGrids shows each grid through a window viewport dots wide that
+// looks scroll dots into a loop of the grid followed by viewport blank dots.
+// Grids are padded to the widest so all lines loop together.
+func scrollGrids(grids [][][]bool, scroll, viewport int) [][][]bool {
+	width := 0
+	for _, grid := range grids {
+		width = max(width, len(grid[0]))
+	}
+	period := width + viewport
+
+	out := make([][][]bool, len(grids))
+	for i, grid := range grids {
+		out[i] = make([][]bool, len(grid))
+		for y, row := range grid {
+			out[i][y] = make([]bool, viewport)
+			for x := range out[i][y] {
+				c := ((scroll+x)%period+period)%period - viewport
+				out[i][y][x] = c >= 0 && c < len(row) && row[c]
+			}
+		}
+	}
+	return out
+}
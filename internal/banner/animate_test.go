@@ -0,0 +1,116 @@
+package banner
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRevealCounts(t *testing.T) {
+	lines := []string{"あいう", "えお"}
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{0, []int{3, 2}},
+		{2, []int{2, 0}},
+		{4, []int{3, 1}},
+		{9, []int{3, 2}},
+	}
+	for _, tt := range tests {
+		if got := revealCounts(lines, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("revealCounts(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestScrollGrids(t *testing.T) {
+	grids := [][][]bool{{{true, false, true}}, {{true}}}
+	tests := []struct {
+		scroll int
+		want   []string
+	}{
+		{0, []string{"..", ".."}},
+		{1, []string{".#", ".#"}},
+		{2, []string{"#.", "#."}},
+		{4, []string{"#.", ".."}},
+		{5, []string{"..", ".."}}, // one full loop
+	}
+	for _, tt := range tests {
+		got := scrollGrids(grids, tt.scroll, 2)
+		rows := []string{gridString(got[0])[0], gridString(got[1])[0]}
+		if !slices.Equal(rows, tt.want) {
+			t.Errorf("scrollGrids(%d) = %q, want %q", tt.scroll, rows, tt.want)
+		}
+	}
+}
+
+func TestFrames_Typewriter(t *testing.T) {
+	face := newTestFace(t)
+	frames := Frames(face, "あい\nう", Options{}, AnimTypewriter)
+	if len(frames) != 3 {
+		t.Fatalf("got %d frames, want one per character", len(frames))
+	}
+
+	first := strings.Split(Generate(face, "あい\nう", frames[0]), "\n")
+	last := strings.Split(Generate(face, "あい\nう", frames[2]), "\n")
+	full := Generate(face, "あい\nう", Options{})
+	if strings.Count(strings.Join(last, ""), "█") != strings.Count(full, "█") {
+		t.Error("the last frame should show the whole text")
+	}
+	if len(first) != len(last) || VisibleWidth(first[1]) != VisibleWidth(last[1]) {
+		t.Error("hidden characters should keep their space")
+	}
+	if strings.Count(strings.Join(first, ""), "█") >= strings.Count(full, "█") {
+		t.Error("the first frame should hide the later characters")
+	}
+}
+
+func TestFrames_Marquee(t *testing.T) {
+	face := newTestFace(t)
+	textWidth := len(buildGrid(face, "AB")[0])
+
+	frames := Frames(face, "AB", Options{Width: 20}, AnimMarquee)
+	if len(frames) != textWidth+10 {
+		t.Fatalf("got %d frames, want text width %d + viewport 10", len(frames), textWidth)
+	}
+	for _, i := range []int{0, 5, len(frames) - 1} {
+		for _, line := range strings.Split(Generate(face, "AB", frames[i]), "\n") {
+			if line != "" && VisibleWidth(line) != 20 {
+				t.Errorf("frame %d line %q is %d columns wide, want 20", i, line, VisibleWidth(line))
+			}
+		}
+	}
+	if got := Generate(face, "AB", frames[0]); strings.Contains(got, "█") {
+		t.Error("the text should start outside the window")
+	}
+
+	frames = Frames(face, "AB", Options{}, AnimMarquee)
+	if len(frames) != 2*textWidth {
+		t.Errorf("got %d frames, want a window as wide as the text", len(frames))
+	}
+}
+
+func TestFrames_Cycle(t *testing.T) {
+	face := newTestFace(t)
+	frames := Frames(face, "AB", Options{}, AnimCycle)
+	if len(frames) != cycleFrames {
+		t.Fatalf("got %d frames, want %d", len(frames), cycleFrames)
+	}
+	a := Generate(face, "AB", frames[0])
+	b := Generate(face, "AB", frames[cycleFrames/2])
+	if !strings.Contains(a, "\033[38;2;") {
+		t.Error("a color cycle without a color should use the rainbow")
+	}
+	if a == b {
+		t.Error("the colors should move between frames")
+	}
+}
+
+func TestFrames_None(t *testing.T) {
+	face := newTestFace(t)
+	opts := Options{Color: "c"}
+	if frames := Frames(face, "AB", opts, AnimNone); len(frames) != 1 || frames[0] != opts {
+		t.Errorf("Frames(AnimNone) = %+v, want the options as a single frame", frames)
+	}
+}
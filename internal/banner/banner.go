@@ -1,7 +1,6 @@
 package banner
 
 import (
-	"math"
 	"strings"
 
 	mcolor "github.com/qraqras/misaki-banner/internal/color"
@@ -15,6 +14,18 @@ const (
 	ShadowNone    ShadowMode = ""        // `██ `
 	ShadowOutline ShadowMode = "outline" // `██╗`
 	ShadowSolid   ShadowMode = "solid"   // `░░▄`
+	ShadowGlow    ShadowMode = "glow"    // `░██░` border around every lit dot
+)
+
+// CompactMode selects a denser rendering that packs several dots into one
+// terminal character.
+type CompactMode string
+
+const (
+	CompactNone    CompactMode = ""        // 1x1 dots per `██`
+	CompactHalf    CompactMode = "half"    // 1x2 dots per `▀▄█`
+	CompactQuarter CompactMode = "quarter" // 2x2 dots per `▘▚▟`
+	CompactBraille CompactMode = "braille" // 2x4 dots per `⠓⣿⡇`
 )
 
 // Options controls how the banner is rendered.
@@ -22,6 +33,50 @@ type Options struct {
 	Shadow   ShadowMode // shadow rendering style
 	Color    string     // text color (RGB format "r,g,b" or preset name)
 	Gradient bool       // enable gradient effect (light to dark)
+	// GradientColors is a comma-separated list of gradient stops such as
+	// "ff0000,00ff00,0000ff". It enables the gradient and overrides Color.
+	GradientColors string
+	GradientDir    GradientDirection // gradient direction (default horizontal)
+	Palette        string            // named gradient palette; overrides GradientColors
+	Vertical       bool              // lay text out top-to-bottom, right-to-left (tategaki)
+	Compact        CompactMode       // pack dots into block characters (shadow is ignored)
+	// Background is the background color of terminal output, or a
+	// comma-separated list of gradient stops that follow GradientDir.
+	Background  string
+	ShadowDir   ShadowDirection // direction the shadow falls (default bottom-right)
+	ShadowDepth int             // cells the shadow extrudes; 0 means 1
+	// ShadowColor colors shadow cells instead of the text color. Like
+	// Background it is a single color or a list of gradient stops.
+	ShadowColor string
+	Invert      bool      // cut the glyphs out of a filled block
+	CharSet     *CharSet  // custom characters overriding those of the shadow mode
+	ScaleX      int       // horizontal magnification of every dot; 0 means 1
+	ScaleY      int       // vertical magnification of every dot; 0 means 1
+	Smooth      bool      // smooth diagonals with Scale2x when scaling by multiples of 2
+	Align       Alignment // alignment of the lines of a multi-line banner
+	Margin      int       // blank columns on the left and lines above and below the banner
+	Padding     int       // empty dots around every line, shown by Background and Invert
+	LineSpacing int       // blank lines between lines of text; 0 means 1, negative means none
+	// Width wraps each line of text to fit this many terminal columns,
+	// following Japanese line-breaking rules. 0 disables wrapping, as
+	// does vertical layout.
+	Width int
+	// ColorProfile is the terminal color depth colors are quantized to.
+	// The zero value emits 24-bit color.
+	ColorProfile mcolor.Profile
+
+	// The fields below hold the state of one animation frame; see Frames.
+	Reveal int // draw only the first Reveal runes, keeping the layout; 0 draws all
+	// Viewport shows every line through a window this many dots wide that
+	// looks Scroll dots into a loop of the text followed by Viewport blank
+	// dots. Lines are not wrapped. 0 disables the window.
+	Viewport int
+	Scroll   int
+	// Phase shifts gradients by this fraction of their length. When it is
+	// non-zero the last gradient stop blends back into the first, and a
+	// gradient without stops runs its hue shift there and back, so the
+	// colors cycle seamlessly as Phase goes from 0 to 1.
+	Phase float64
 }
 
 // glyphInfo holds bitmap and width information for a single glyph.
@@ -34,27 +89,213 @@ type glyphInfo struct {
 type colorInfo struct {
 	color    mcolor.RGB
 	hasColor bool
+	gradient gradient
+	profile  mcolor.Profile
+
+	background fill // cell background
+	shadow     fill // shadow color overriding the text color
+}
+
+// fill is an optional color, or gradient, parsed from an Options field
+// such as Background.
+type fill struct {
+	color    mcolor.RGB
+	ok       bool
+	gradient gradient
+}
+
+// at returns the color of the fill at (y, x).
+func (f fill) at(y, x int) mcolor.RGB {
+	return f.gradient.at(f.color, y, x)
 }
 
 // Generate creates an ASCII-art banner string from the given text.
-// If text contains newlines, each line is rendered separately and joined.
+// If text contains newlines, each line is rendered separately, aligned and
+// joined.
+// In vertical mode all lines are laid out as columns of a single banner.
 func Generate(face *mfont.Face, text string, opts Options) string {
-	// Handle multi-line text
-	if strings.Contains(text, "\n") {
-		var parts []string
-		for _, line := range strings.Split(text, "\n") {
-			if line == "" {
-				continue
+	// Parse color once, not per-pixel
+	ci := parseColorInfo(opts)
+
+	var blocks [][]string
+	for _, grid := range layoutText(face, text, opts) {
+		height, totalWidth := len(grid), len(grid[0])
+		gci := ci.spanning(height, totalWidth)
+		var lines []string
+		if opts.Compact != CompactNone {
+			lines = renderCompact(grid, height, totalWidth, opts.Compact, gci)
+		} else {
+			lines = renderWithCharSet(grid, height, totalWidth, opts.charSet(), shadowSpecOf(opts), gci)
+		}
+		if opts.keepsBlankRows() {
+			blocks = append(blocks, lines)
+		} else {
+			blocks = append(blocks, strings.Split(trimBlankLines(lines), "\n"))
+		}
+	}
+	return joinBlocks(blocks, opts)
+}
+
+// layoutText builds the glyph grids for text, one per rendered block.
+// Empty lines are skipped and long lines are wrapped to opts.Width.
+func layoutText(face *mfont.Face, text string, opts Options) [][][]bool {
+	grids := glyphGrids(face, textLines(face, text, opts), opts)
+	if opts.Viewport > 0 {
+		grids = scrollGrids(grids, opts.Scroll, opts.Viewport)
+	}
+	for i, grid := range grids {
+		grid = scaleGrid(grid, opts.ScaleX, opts.ScaleY, opts.Smooth)
+		grid = padGrid(grid, opts.Padding)
+		if opts.Invert {
+			grid = invertGrid(grid)
+		}
+		grids[i] = grid
+	}
+	return grids
+}
+
+// textLines returns the non-empty lines of text, wrapped to opts.Width.
+func textLines(face *mfont.Face, text string, opts Options) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if maxDots := opts.wrapDots(); maxDots > 0 {
+		var wrapped []string
+		for _, line := range lines {
+			wrapped = append(wrapped, wrapLine(face, line, maxDots)...)
+		}
+		lines = wrapped
+	}
+	return lines
+}
+
+// glyphGrids lays out lines at one dot per glyph dot, hiding the glyphs
+// past opts.Reveal.
+func glyphGrids(face *mfont.Face, lines []string, opts Options) [][][]bool {
+	if len(lines) == 0 {
+		return nil
+	}
+	shown := revealCounts(lines, opts.Reveal)
+	if opts.Vertical {
+		grid := buildVerticalGrid(face, lines)
+		if opts.Reveal > 0 {
+			hideVerticalGlyphs(grid, face.FontSize(), lines, shown)
+		}
+		return [][][]bool{grid}
+	}
+	grids := make([][][]bool, 0, len(lines))
+	for i, line := range lines {
+		grid := buildGrid(face, line)
+		if opts.Reveal > 0 {
+			hideColumns(grid, runesWidth(face, []rune(line)[:shown[i]]))
+		}
+		grids = append(grids, grid)
+	}
+	return grids
+}
+
+// invertGrid returns the complement of grid inside a one-dot frame,
+// so the glyphs appear cut out of a filled block.
+func invertGrid(grid [][]bool) [][]bool {
+	h, w := len(grid), len(grid[0])
+	inv := make([][]bool, h+2)
+	for y := range inv {
+		inv[y] = make([]bool, w+2)
+		for x := range inv[y] {
+			inv[y][x] = y == 0 || y == h+1 || x == 0 || x == w+1 || !grid[y-1][x-1]
+		}
+	}
+	return inv
+}
+
+// parseColorInfo parses the text color, background and gradients in opts.
+// An invalid or empty color yields colorInfo with hasColor unset, and
+// likewise for the background.
+func parseColorInfo(opts Options) colorInfo {
+	ci := parseTextColor(opts)
+	ci.profile = opts.ColorProfile
+	ci.background = parseFill(opts.Background, opts.GradientDir)
+	ci.shadow = parseFill(opts.ShadowColor, opts.GradientDir)
+	if opts.Phase != 0 {
+		ci.gradient = ci.gradient.cycled(opts.Phase)
+		ci.background.gradient = ci.background.gradient.cycled(opts.Phase)
+		ci.shadow.gradient = ci.shadow.gradient.cycled(opts.Phase)
+	}
+	return ci
+}
+
+// parseTextColor parses the text color and gradient in opts.
+func parseTextColor(opts Options) colorInfo {
+	ci := colorInfo{gradient: gradient{enabled: opts.Gradient, dir: opts.GradientDir}}
+	if opts.Palette != "" || opts.GradientColors != "" {
+		var stops []mcolor.RGB
+		if opts.Palette != "" {
+			var ok bool
+			if stops, ok = mcolor.LookupPalette(opts.Palette); !ok {
+				return colorInfo{}
+			}
+		} else {
+			var err error
+			if stops, err = mcolor.ParseColorList(opts.GradientColors); err != nil {
+				return colorInfo{}
 			}
-			parts = append(parts, Generate(face, line, opts))
 		}
-		return strings.Join(parts, "\n\n")
+		ci.gradient.enabled = true
+		ci.gradient.stops = stops
+		ci.color, ci.hasColor = stops[0], true
+		return ci
+	}
+	if opts.Color == "" {
+		return colorInfo{}
+	}
+	c, err := mcolor.ParseColor(opts.Color)
+	if err != nil {
+		return colorInfo{}
 	}
+	ci.color, ci.hasColor = c, true
+	return ci
+}
 
-	runes := []rune(text)
-	if len(runes) == 0 {
-		return ""
+// parseFill parses spec as a single color, or else as gradient stops
+// along dir. An invalid or empty spec yields a fill with ok unset.
+func parseFill(spec string, dir GradientDirection) fill {
+	f := fill{gradient: gradient{dir: dir}}
+	if spec == "" {
+		return f
+	}
+	if c, err := mcolor.ParseColor(spec); err == nil {
+		f.color, f.ok = c, true
+		return f
 	}
+	stops, err := mcolor.ParseColorList(spec)
+	if err != nil {
+		return f
+	}
+	f.gradient.enabled, f.gradient.stops = true, stops
+	f.color, f.ok = stops[0], true
+	return f
+}
+
+// spanning returns a copy of ci whose gradients span a grid of the given size.
+func (ci colorInfo) spanning(height, width int) colorInfo {
+	ci.gradient.height, ci.gradient.width = height, width
+	ci.background.gradient.height, ci.background.gradient.width = height, width
+	ci.shadow.gradient.height, ci.shadow.gradient.width = height, width
+	return ci
+}
+
+// at returns the color of the pixel at (y, x).
+func (ci colorInfo) at(y, x int) mcolor.RGB {
+	return ci.gradient.at(ci.color, y, x)
+}
+
+// buildGrid lays out the glyphs of a single non-empty line of text side by
+// side and returns the combined 2D bool grid.
+func buildGrid(face *mfont.Face, text string) [][]bool {
+	runes := []rune(text)
 
 	height := face.FontSize()
 
@@ -89,124 +330,107 @@ func Generate(face *mfont.Face, text string, opts Options) string {
 			xOff += g.width
 		}
 	}
-
-	// Parse color once, not per-pixel
-	var ci colorInfo
-	if opts.Color != "" {
-		c, err := mcolor.ParseColor(opts.Color)
-		if err == nil {
-			ci = colorInfo{color: c, hasColor: true}
-		}
-	}
-
-	lines := renderWithCharSet(grid, height, totalWidth, opts, getCharSet(opts.Shadow), ci)
-	return trimBlankLines(lines)
+	return grid
 }
 
 // colorPixel returns the string wrapped with the ANSI color for the given pixel.
-// It calculates the gradient based on the pixel's position across the entire string.
-func colorPixel(s string, y, x int, totalWidth int, opts Options, baseColor mcolor.RGB, hasColor bool) string {
-	if !hasColor {
+// It calculates the gradient based on the pixel's position across the entire grid
+// and quantizes it to the color profile.
+func colorPixel(s string, y, x int, ci colorInfo) string {
+	if !ci.hasColor || ci.profile == mcolor.ProfileNone {
 		return s
 	}
+	return ci.profile.ANSI(ci.at(y, x)) + s + mcolor.Reset
+}
 
-	var color mcolor.RGB
-
-	if opts.Gradient {
-		// Gradient mode: left to right, slightly brighter to slightly darker
-		if totalWidth <= 1 {
-			color = baseColor
-		} else {
-			// Normalize x position to [0, 1]
-			t := float64(x) / float64(totalWidth-1)
-			if t < 0 {
-				t = 0
-			}
-			if t > 1 {
-				t = 1
-			}
-
-			// Create a natural gradient by shifting hue and lightness
-			// Hue: Left +20 -> Center 0 -> Right -20 (degrees)
-			// Lightness: Left +0.2 -> Center 0 -> Right +0.2 (V-shape, factor 0-1)
-			hueDelta := 20.0 - (40.0 * t)       // +20 to -20
-			lightDelta := 0.2 * math.Abs(t-0.5) // +0.2 to 0 to +0.2
-			color = mcolor.ShiftColor(baseColor, hueDelta, lightDelta)
-		}
-	} else {
-		// Single color mode
-		color = baseColor
+// shadowPixel returns the string wrapped with the shadow color cast by the
+// text pixel at (y, x), which is the text color unless ShadowColor is set.
+func shadowPixel(s string, y, x int, ci colorInfo) string {
+	if !ci.shadow.ok {
+		return colorPixel(s, y, x, ci)
 	}
-
-	return color.ANSI() + s + mcolor.Reset
+	if ci.profile == mcolor.ProfileNone {
+		return s
+	}
+	return ci.profile.ANSI(ci.shadow.at(y, x)) + s + mcolor.Reset
 }
 
-// charSet defines the character set for different rendering modes.
-type charSet struct {
-	textOn          string // character for main text pixels
-	textOff         string // character for empty pixels
-	shadowLeftAbove string // left && above
-	shadowLeftDiag  string // left && diagonal
-	shadowLeft      string // left only
-	shadowAboveDiag string // above && diagonal
-	shadowAbove     string // above only
-	shadowDiag      string // diagonal only
+// backgroundPixel returns the string, which may already carry a foreground
+// color, drawn over the background color of the cell at (y, x).
+func backgroundPixel(s string, y, x int, ci colorInfo) string {
+	if !ci.background.ok || ci.profile == mcolor.ProfileNone {
+		return s
+	}
+	bg := ci.profile.BackgroundANSI(ci.background.at(y, x))
+	if strings.HasSuffix(s, mcolor.Reset) {
+		return bg + s
+	}
+	return bg + s + mcolor.Reset
 }
 
-// getCharSet returns the character set for the given shadow mode.
-func getCharSet(mode ShadowMode) charSet {
-	switch mode {
-	case ShadowOutline:
-		return charSet{
-			textOn:          "██",
-			textOff:         "  ",
-			shadowLeftAbove: "╔═",
-			shadowLeftDiag:  "║ ",
-			shadowLeft:      "╗ ",
-			shadowAboveDiag: "══",
-			shadowAbove:     "╚═",
-			shadowDiag:      "╝ ",
-		}
-	case ShadowSolid:
-		return charSet{
-			textOn:          "░░",
-			textOff:         "  ",
-			shadowLeftAbove: "█▀",
-			shadowLeftDiag:  "█ ",
-			shadowLeft:      "▄ ",
-			shadowAboveDiag: "▀▀",
-			shadowAbove:     " ▀",
-			shadowDiag:      "▀ ",
-		}
+// shadowKind classifies an empty cell by which of its neighbours are lit.
+type shadowKind int
+
+const (
+	shadowNone      shadowKind = iota // no lit neighbour
+	shadowLeftAbove                   // left && above
+	shadowLeftDiag                    // left && diagonal
+	shadowLeft                        // left only
+	shadowAboveDiag                   // above && diagonal
+	shadowAbove                       // above only
+	shadowDiag                        // diagonal only
+	shadowExtrude                     // lit cell within the shadow depth
+	shadowGlow                        // lit cell within the glow radius
+)
+
+// shadowAt classifies the empty cell at (y, x) by its left, above and diagonal
+// (above-left) neighbours. It also returns the position of the text pixel
+// that casts the shadow, so the shadow can share that pixel's color.
+func shadowAt(isOn func(y, x int) bool, y, x int) (shadowKind, int, int) {
+	left := isOn(y, x-1)       // left
+	above := isOn(y-1, x)      // above
+	diagonal := isOn(y-1, x-1) // diagonal (above-left)
+
+	switch {
+	case left && above:
+		return shadowLeftAbove, y, x - 1
+	case left && diagonal:
+		return shadowLeftDiag, y, x - 1
+	case left:
+		return shadowLeft, y, x - 1
+	case above && diagonal:
+		return shadowAboveDiag, y - 1, x
+	case above:
+		return shadowAbove, y - 1, x
+	case diagonal:
+		return shadowDiag, y - 1, x - 1
 	default:
-		return charSet{
-			textOn:  "██",
-			textOff: "  ",
-		}
+		return shadowNone, y, x
 	}
 }
 
-// renderWithCharSet renders a glyph grid with the given character set.
-func renderWithCharSet(grid [][]bool, h, w int, opts Options, chars charSet, ci colorInfo) []string {
+// renderWithCharSet renders a glyph grid with the given character set
+// and shadow geometry.
+func renderWithCharSet(grid [][]bool, h, w int, chars CharSet, sh shadowSpec, ci colorInfo) []string {
 	// For non-shadow modes, use simple rendering
-	if chars.shadowLeftAbove == "" {
-		return renderSimple(grid, h, w, opts, chars, ci)
+	if !chars.hasShadow() {
+		return renderSimple(grid, h, w, chars, ci)
 	}
 	// For shadow modes, use shadow rendering
-	return renderShadow(grid, h, w, opts, chars, ci)
+	return renderShadow(grid, h, w, chars, sh, ci)
 }
 
 // renderSimple renders a glyph grid without shadow effects.
-func renderSimple(grid [][]bool, height, width int, opts Options, chars charSet, ci colorInfo) []string {
+func renderSimple(grid [][]bool, height, width int, chars CharSet, ci colorInfo) []string {
 	lines := make([]string, height)
 	for y := 0; y < height; y++ {
 		var sb strings.Builder
 		for x := 0; x < width; x++ {
+			cell := chars.TextOff
 			if grid[y][x] {
-				sb.WriteString(colorPixel(chars.textOn, y, x, width, opts, ci.color, ci.hasColor))
-			} else {
-				sb.WriteString(chars.textOff)
+				cell = colorPixel(chars.TextOn, y, x, ci)
 			}
+			sb.WriteString(backgroundPixel(cell, y, x, ci))
 		}
 		lines[y] = sb.String()
 	}
@@ -214,7 +438,7 @@ func renderSimple(grid [][]bool, height, width int, opts Options, chars charSet,
 }
 
 // renderShadow renders a glyph grid with shadow effects as a string array.
-func renderShadow(grid [][]bool, h, w int, opts Options, chars charSet, ci colorInfo) []string {
+func renderShadow(grid [][]bool, h, w int, chars CharSet, sh shadowSpec, ci colorInfo) []string {
 	isOn := func(y, x int) bool {
 		if y < 0 || y >= h || x < 0 || x >= w {
 			return false
@@ -222,49 +446,27 @@ func renderShadow(grid [][]bool, h, w int, opts Options, chars charSet, ci color
 		return grid[y][x]
 	}
 
-	// Extended canvas: +1 row for bottom shadow, +1 col for right shadow
-	outH := h + 1
-	outW := w + 1
+	// Extended canvas with room for the shadow on its side of the grid
+	outH, outW, oy, ox := sh.canvas(h, w)
 
 	lines := make([]string, outH)
-	for y := 0; y < outH; y++ {
+	for cy := 0; cy < outH; cy++ {
 		var sb strings.Builder
-		for x := 0; x < outW; x++ {
+		for cx := 0; cx < outW; cx++ {
+			y, x := cy-oy, cx-ox
 			if isOn(y, x) {
-				sb.WriteString(colorPixel(chars.textOn, y, x, w, opts, ci.color, ci.hasColor))
+				sb.WriteString(backgroundPixel(colorPixel(chars.TextOn, y, x, ci), y, x, ci))
 				continue
 			}
 
-			left := isOn(y, x-1)       // left
-			above := isOn(y-1, x)      // above
-			diagonal := isOn(y-1, x-1) // diagonal (above-left)
-
-			var shadowStr string
-			switch {
-			case left && above:
-				shadowStr = chars.shadowLeftAbove
-				shadowStr = colorPixel(shadowStr, y, x-1, w, opts, ci.color, ci.hasColor)
-			case left && diagonal:
-				shadowStr = chars.shadowLeftDiag
-				shadowStr = colorPixel(shadowStr, y, x-1, w, opts, ci.color, ci.hasColor)
-			case left:
-				shadowStr = chars.shadowLeft
-				shadowStr = colorPixel(shadowStr, y, x-1, w, opts, ci.color, ci.hasColor)
-			case above && diagonal:
-				shadowStr = chars.shadowAboveDiag
-				shadowStr = colorPixel(shadowStr, y-1, x, w, opts, ci.color, ci.hasColor)
-			case above:
-				shadowStr = chars.shadowAbove
-				shadowStr = colorPixel(shadowStr, y-1, x, w, opts, ci.color, ci.hasColor)
-			case diagonal:
-				shadowStr = chars.shadowDiag
-				shadowStr = colorPixel(shadowStr, y-1, x-1, w, opts, ci.color, ci.hasColor)
-			default:
-				shadowStr = chars.textOff
+			kind, sy, sx := sh.at(isOn, y, x)
+			shadowStr := chars.shadow(kind)
+			if kind != shadowNone {
+				shadowStr = shadowPixel(shadowStr, sy, sx, ci)
 			}
-			sb.WriteString(shadowStr)
+			sb.WriteString(backgroundPixel(shadowStr, y, x, ci))
 		}
-		lines[y] = sb.String()
+		lines[cy] = sb.String()
 	}
 	return lines
 }
@@ -1,9 +1,11 @@
 package banner
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 
+	mcolor "github.com/qraqras/misaki-banner/internal/color"
 	mfont "github.com/qraqras/misaki-banner/internal/font"
 )
 
@@ -136,3 +138,106 @@ func TestTrimBlankLines(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerate_ColorProfile(t *testing.T) {
+	face := newTestFace(t)
+	tests := []struct {
+		profile mcolor.Profile
+		want    string
+	}{
+		{mcolor.Profile256, "\033[38;5;"},
+		{mcolor.Profile16, "\033[96m"},
+	}
+	for _, tt := range tests {
+		result := Generate(face, "A", Options{Color: "c", Gradient: true, ColorProfile: tt.profile})
+		if !strings.Contains(result, tt.want) || strings.Contains(result, "38;2;") {
+			t.Errorf("profile %d output should use %q sequences only", tt.profile, tt.want)
+		}
+	}
+
+	plain := Generate(face, "A", Options{})
+	if got := Generate(face, "A", Options{Color: "c", ColorProfile: mcolor.ProfileNone}); got != plain {
+		t.Error("ProfileNone output should match uncolored output")
+	}
+}
+
+func TestGenerate_Background(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "A", Options{Color: "c", Background: "navy"})
+	for i, line := range strings.Split(result, "\n") {
+		if !strings.HasPrefix(line, "\033[48;2;0;0;128m") {
+			t.Errorf("line %d does not start with the background color: %q", i, line)
+		}
+	}
+	if !strings.Contains(result, "\033[48;2;0;0;128m\033[38;2;0;255;255m██") {
+		t.Error("text pixels should carry both background and foreground colors")
+	}
+
+	gradient := Generate(face, "A", Options{Background: "ff0000,0000ff"})
+	if !strings.Contains(gradient, "\033[48;2;255;0;0m") || !strings.Contains(gradient, "\033[48;2;0;0;255m") {
+		t.Error("background gradient should span from the first to the last stop")
+	}
+}
+
+func TestInvertGrid(t *testing.T) {
+	grid := [][]bool{
+		{true, false},
+		{false, true},
+	}
+	want := [][]bool{
+		{true, true, true, true},
+		{true, false, true, true},
+		{true, true, false, true},
+		{true, true, true, true},
+	}
+	got := invertGrid(grid)
+	for y := range want {
+		for x := range want[y] {
+			if got[y][x] != want[y][x] {
+				t.Errorf("invertGrid()[%d][%d] = %v, want %v", y, x, got[y][x], want[y][x])
+			}
+		}
+	}
+}
+
+func TestGenerate_Invert(t *testing.T) {
+	face := newTestFace(t)
+	for _, shadow := range []ShadowMode{ShadowNone, ShadowOutline} {
+		normal := Generate(face, "A", Options{Shadow: shadow})
+		inverted := Generate(face, "A", Options{Shadow: shadow, Invert: true})
+		if normal == inverted {
+			t.Errorf("shadow %q: inverted output matches normal output", shadow)
+		}
+		lines := strings.Split(inverted, "\n")
+		if !strings.HasPrefix(lines[0], "████") {
+			t.Errorf("shadow %q: inverted output should start with a filled frame, got %q", shadow, lines[0])
+		}
+	}
+}
+
+func TestGenerate_ShadowColor(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "A", Options{Color: "c", Shadow: ShadowOutline, ShadowColor: "444444"})
+	if !strings.Contains(result, "\033[38;2;0;255;255m██") {
+		t.Error("text pixels should keep the text color")
+	}
+	shadowSeq := regexp.MustCompile(`\033\[38;2;68;68;68m([^\033]+)`).FindAllStringSubmatch(result, -1)
+	if len(shadowSeq) == 0 {
+		t.Fatal("output does not contain the shadow color")
+	}
+	for _, m := range shadowSeq {
+		if strings.Contains(m[1], "█") {
+			t.Errorf("text characters %q are drawn in the shadow color", m[1])
+		}
+	}
+
+	// Without a text color, every escape sequence belongs to the shadow
+	gradient := Generate(face, "A", Options{Shadow: ShadowSolid, ShadowColor: "ff0000,0000ff"})
+	colors := map[string]bool{}
+	for _, seq := range regexp.MustCompile(`\033\[38;2;[\d;]+m`).FindAllString(gradient, -1) {
+		colors[seq] = true
+	}
+	if len(colors) < 3 {
+		t.Errorf("shadow gradient uses %d colors, want a gradient", len(colors))
+	}
+}
@@ -0,0 +1,209 @@
+package banner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/text/width"
+)
+
+// CharSet defines the characters drawn for each kind of cell. Every
+// character should take the same number of terminal columns as TextOn.
+// Shadow characters are named by which neighbours of the empty cell are
+// lit in the classic bottom-right shadow; ShadowExtrude is used for any
+// other direction or depth, and Glow for the border of ShadowGlow.
+type CharSet struct {
+	TextOn          string `json:"text_on"`           // character for main text pixels
+	TextOff         string `json:"text_off"`          // character for empty pixels
+	ShadowLeftAbove string `json:"shadow_left_above"` // left && above
+	ShadowLeftDiag  string `json:"shadow_left_diag"`  // left && diagonal
+	ShadowLeft      string `json:"shadow_left"`       // left only
+	ShadowAboveDiag string `json:"shadow_above_diag"` // above && diagonal
+	ShadowAbove     string `json:"shadow_above"`      // above only
+	ShadowDiag      string `json:"shadow_diag"`       // diagonal only
+	ShadowExtrude   string `json:"shadow_extrude"`    // extruded shadow in any direction or depth
+	Glow            string `json:"glow"`              // border cell of ShadowGlow
+}
+
+// getCharSet returns the character set for the given shadow mode.
+func getCharSet(mode ShadowMode) CharSet {
+	switch mode {
+	case ShadowOutline:
+		return CharSet{
+			TextOn:          "██",
+			TextOff:         "  ",
+			ShadowLeftAbove: "╔═",
+			ShadowLeftDiag:  "║ ",
+			ShadowLeft:      "╗ ",
+			ShadowAboveDiag: "══",
+			ShadowAbove:     "╚═",
+			ShadowDiag:      "╝ ",
+			ShadowExtrude:   "▒▒",
+		}
+	case ShadowSolid:
+		return CharSet{
+			TextOn:          "░░",
+			TextOff:         "  ",
+			ShadowLeftAbove: "█▀",
+			ShadowLeftDiag:  "█ ",
+			ShadowLeft:      "▄ ",
+			ShadowAboveDiag: "▀▀",
+			ShadowAbove:     " ▀",
+			ShadowDiag:      "▀ ",
+			ShadowExtrude:   "██",
+		}
+	case ShadowGlow:
+		return CharSet{
+			TextOn:  "██",
+			TextOff: "  ",
+			Glow:    "░░",
+		}
+	default:
+		return CharSet{
+			TextOn:  "██",
+			TextOff: "  ",
+		}
+	}
+}
+
+// charSet returns the character set of the shadow mode with the non-empty
+// fields of o.CharSet applied on top.
+func (o Options) charSet() CharSet {
+	cs := getCharSet(o.Shadow)
+	if o.CharSet == nil {
+		return cs
+	}
+	for i, f := range o.CharSet.fields() {
+		if *f.value != "" {
+			*cs.fields()[i].value = *f.value
+		}
+	}
+	return cs
+}
+
+// charSetField is a named pointer to a CharSet field.
+type charSetField struct {
+	name  string
+	value *string
+}
+
+// fields returns the fields of cs in declaration order, named as in JSON.
+func (cs *CharSet) fields() []charSetField {
+	return []charSetField{
+		{"text_on", &cs.TextOn},
+		{"text_off", &cs.TextOff},
+		{"shadow_left_above", &cs.ShadowLeftAbove},
+		{"shadow_left_diag", &cs.ShadowLeftDiag},
+		{"shadow_left", &cs.ShadowLeft},
+		{"shadow_above_diag", &cs.ShadowAboveDiag},
+		{"shadow_above", &cs.ShadowAbove},
+		{"shadow_diag", &cs.ShadowDiag},
+		{"shadow_extrude", &cs.ShadowExtrude},
+		{"glow", &cs.Glow},
+	}
+}
+
+// hasShadow reports whether cs defines any shadow or glow character.
+func (cs CharSet) hasShadow() bool {
+	for _, f := range cs.fields()[2:] {
+		if *f.value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// shadow returns the characters for the given shadow kind,
+// or TextOff if the set does not define them.
+func (cs CharSet) shadow(kind shadowKind) string {
+	var s string
+	switch kind {
+	case shadowLeftAbove:
+		s = cs.ShadowLeftAbove
+	case shadowLeftDiag:
+		s = cs.ShadowLeftDiag
+	case shadowLeft:
+		s = cs.ShadowLeft
+	case shadowAboveDiag:
+		s = cs.ShadowAboveDiag
+	case shadowAbove:
+		s = cs.ShadowAbove
+	case shadowDiag:
+		s = cs.ShadowDiag
+	case shadowExtrude:
+		s = cs.ShadowExtrude
+	case shadowGlow:
+		s = cs.Glow
+	}
+	if s == "" {
+		return cs.TextOff
+	}
+	return s
+}
+
+// validate checks that every defined character is as wide as TextOn,
+// so the rendered columns stay aligned.
+func (cs CharSet) validate() error {
+	want := displayWidth(cs.TextOn)
+	if want == 0 {
+		return fmt.Errorf("text_on must not be empty")
+	}
+	for _, f := range cs.fields()[1:] {
+		if *f.value == "" {
+			continue
+		}
+		if got := displayWidth(*f.value); got != want {
+			return fmt.Errorf("%s %q is %d columns wide, want %d like text_on", f.name, *f.value, got, want)
+		}
+	}
+	return nil
+}
+
+// displayWidth returns the number of terminal columns s occupies,
+// counting East Asian wide and fullwidth runes as two.
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		switch width.LookupRune(r).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// ParseCharSet reads a character set from JSON of the form
+// {"text_on": "##", "text_off": "  ", "shadow_diag": "\\ ", ...}.
+// Omitted fields keep the characters of the shadow mode.
+func ParseCharSet(r io.Reader) (*CharSet, error) {
+	var cs CharSet
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cs); err != nil {
+		return nil, fmt.Errorf("invalid charset: %w", err)
+	}
+	return &cs, nil
+}
+
+// LoadCharSet reads a character set from spec, which is either inline JSON
+// starting with "{" or the path of a JSON file.
+func LoadCharSet(spec string) (*CharSet, error) {
+	if strings.HasPrefix(strings.TrimSpace(spec), "{") {
+		return ParseCharSet(strings.NewReader(spec))
+	}
+	f, err := os.Open(spec)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	cs, err := ParseCharSet(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", spec, err)
+	}
+	return cs, nil
+}
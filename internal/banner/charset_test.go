@@ -0,0 +1,72 @@
+package banner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCharSet(t *testing.T) {
+	cs, err := ParseCharSet(strings.NewReader(`{"text_on": "##", "shadow_diag": "\\."}`))
+	if err != nil {
+		t.Fatalf("ParseCharSet returned error: %v", err)
+	}
+	if cs.TextOn != "##" || cs.ShadowDiag != `\.` || cs.TextOff != "" {
+		t.Errorf("ParseCharSet = %+v", cs)
+	}
+
+	if _, err := ParseCharSet(strings.NewReader(`{"text_onn": "##"}`)); err == nil {
+		t.Error("ParseCharSet with an unknown field expected error, got nil")
+	}
+}
+
+func TestLoadCharSet_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "charset.json")
+	if err := os.WriteFile(path, []byte(`{"text_on": "▓▓"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cs, err := LoadCharSet(path)
+	if err != nil {
+		t.Fatalf("LoadCharSet returned error: %v", err)
+	}
+	if cs.TextOn != "▓▓" {
+		t.Errorf("TextOn = %q, want %q", cs.TextOn, "▓▓")
+	}
+}
+
+func TestGenerate_CustomCharSet(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "A", Options{CharSet: &CharSet{TextOn: "##", TextOff: ".."}})
+	if strings.Contains(result, "█") || !strings.Contains(result, "##") || !strings.Contains(result, "..") {
+		t.Errorf("custom charset not applied:\n%s", result)
+	}
+
+	// Fields left empty keep the characters of the shadow mode
+	outline := Generate(face, "A", Options{Shadow: ShadowOutline, CharSet: &CharSet{TextOn: "##"}})
+	if !strings.Contains(outline, "##") || !strings.Contains(outline, "╗") {
+		t.Errorf("custom charset should merge with the outline shadow:\n%s", outline)
+	}
+
+	// Shadow characters alone enable the shadow
+	shadow := Generate(face, "A", Options{CharSet: &CharSet{ShadowDiag: "\\\\"}})
+	if !strings.Contains(shadow, "\\\\") {
+		t.Errorf("custom shadow characters should draw a shadow:\n%s", shadow)
+	}
+}
+
+func TestOptionsValidate_CharSetWidth(t *testing.T) {
+	err := Options{CharSet: &CharSet{TextOn: "#"}}.Validate()
+	var optErr *OptionError
+	if !errors.As(err, &optErr) || optErr.Option != "CharSet" {
+		t.Fatalf("Validate() = %v, want CharSet OptionError", err)
+	}
+	if !strings.Contains(err.Error(), "text_off") {
+		t.Errorf("Validate() = %v, want it to name text_off", err)
+	}
+
+	if err := (Options{CharSet: &CharSet{TextOn: "＃", TextOff: "  "}}).Validate(); err != nil {
+		t.Errorf("Validate() with a fullwidth character = %v, want nil", err)
+	}
+}
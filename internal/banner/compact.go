@@ -0,0 +1,98 @@
+package banner
+
+import (
+	"strings"
+)
+
+// halfBlocks maps a 1x2 dot mask (bit 0: top, bit 1: bottom) to a character.
+var halfBlocks = [4]string{" ", "▀", "▄", "█"}
+
+// quadrantBlocks maps a 2x2 dot mask (bit 0: top-left, bit 1: top-right,
+// bit 2: bottom-left, bit 3: bottom-right) to a character.
+var quadrantBlocks = [16]string{
+	" ", "▘", "▝", "▀",
+	"▖", "▌", "▞", "▛",
+	"▗", "▚", "▐", "▜",
+	"▄", "▙", "▟", "█",
+}
+
+// brailleDots maps a dot of a 2x4 block (bit y*2 + x) to its bit in the
+// Unicode Braille Patterns block, which numbers dots column by column.
+var brailleDots = [8]rune{
+	0x01, 0x08,
+	0x02, 0x10,
+	0x04, 0x20,
+	0x40, 0x80,
+}
+
+// brailleChar maps a 2x4 dot mask to a Braille pattern character (U+2800-U+28FF).
+// An empty block is a plain space so blank rows can be trimmed.
+func brailleChar(mask int) string {
+	if mask == 0 {
+		return " "
+	}
+	r := rune(0x2800)
+	for i, bit := range brailleDots {
+		if mask&(1<<i) != 0 {
+			r |= bit
+		}
+	}
+	return string(r)
+}
+
+// renderCompact renders a glyph grid in the given compact mode.
+func renderCompact(grid [][]bool, h, w int, mode CompactMode, ci colorInfo) []string {
+	switch mode {
+	case CompactHalf:
+		return renderBlocks(grid, h, w, 1, 2, ci, func(mask int) string { return halfBlocks[mask] })
+	case CompactQuarter:
+		return renderBlocks(grid, h, w, 2, 2, ci, func(mask int) string { return quadrantBlocks[mask] })
+	case CompactBraille:
+		return renderBlocks(grid, h, w, 2, 4, ci, brailleChar)
+	default:
+		return renderSimple(grid, h, w, getCharSet(ShadowNone), ci)
+	}
+}
+
+// renderBlocks renders a glyph grid by packing each bw×bh block of dots into
+// a single character. The mask passed to char has bit (y*bw + x) set for each
+// lit dot of the block. A block takes the color of its first lit dot.
+func renderBlocks(grid [][]bool, h, w, bw, bh int, ci colorInfo, char func(mask int) string) []string {
+	isOn := func(y, x int) bool {
+		if y < 0 || y >= h || x < 0 || x >= w {
+			return false
+		}
+		return grid[y][x]
+	}
+
+	outH := (h + bh - 1) / bh
+	outW := (w + bw - 1) / bw
+
+	lines := make([]string, outH)
+	for by := 0; by < outH; by++ {
+		var sb strings.Builder
+		for bx := 0; bx < outW; bx++ {
+			mask := 0
+			cy, cx := -1, -1
+			for dy := 0; dy < bh; dy++ {
+				for dx := 0; dx < bw; dx++ {
+					y, x := by*bh+dy, bx*bw+dx
+					if !isOn(y, x) {
+						continue
+					}
+					mask |= 1 << (dy*bw + dx)
+					if cy < 0 {
+						cy, cx = y, x
+					}
+				}
+			}
+			if mask == 0 {
+				sb.WriteString(backgroundPixel(char(0), by*bh, bx*bw, ci))
+				continue
+			}
+			sb.WriteString(backgroundPixel(colorPixel(char(mask), cy, cx, ci), by*bh, bx*bw, ci))
+		}
+		lines[by] = sb.String()
+	}
+	return lines
+}
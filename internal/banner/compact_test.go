@@ -0,0 +1,83 @@
+package banner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate_CompactHalf(t *testing.T) {
+	face := newTestFace(t)
+	full := strings.Split(Generate(face, "あ", Options{}), "\n")
+	half := strings.Split(Generate(face, "あ", Options{Compact: CompactHalf}), "\n")
+	if len(half) != (len(full)+1)/2 {
+		t.Errorf("CompactHalf height = %d, want %d", len(half), (len(full)+1)/2)
+	}
+	if strings.Contains(strings.Join(half, ""), "██") {
+		t.Error("CompactHalf output should not contain two-column ██ cells")
+	}
+}
+
+func TestRenderBlocks_Quarter(t *testing.T) {
+	grid := [][]bool{
+		{true, false, true, true},
+		{false, true, false, true},
+	}
+	lines := renderCompact(grid, 2, 4, CompactQuarter, colorInfo{})
+	if len(lines) != 1 {
+		t.Fatalf("renderCompact height = %d, want 1", len(lines))
+	}
+	if lines[0] != "▚▜" {
+		t.Errorf("renderCompact = %q, want %q", lines[0], "▚▜")
+	}
+}
+
+func TestRenderBlocks_HalfOddHeight(t *testing.T) {
+	grid := [][]bool{{true}, {false}, {true}}
+	lines := renderCompact(grid, 3, 1, CompactHalf, colorInfo{})
+	want := []string{"▀", "▀"}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("renderCompact = %q, want %q", lines, want)
+	}
+}
+
+func TestGenerate_CompactWithColor(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "A", Options{Compact: CompactQuarter, Color: "c"})
+	if !strings.Contains(result, "\033[38;2;") {
+		t.Error("compact output with color does not contain ANSI escape sequence")
+	}
+}
+
+func TestBrailleChar(t *testing.T) {
+	tests := []struct {
+		mask int
+		want string
+	}{
+		{0, " "},
+		{1 << 0, "⠁"}, // top-left
+		{1 << 1, "⠈"}, // top-right
+		{1 << 6, "⡀"}, // bottom-left
+		{1 << 7, "⢀"}, // bottom-right
+		{0xFF, "⣿"},   // all dots
+		{0x55, "⡇"},   // left column
+	}
+	for _, tt := range tests {
+		if got := brailleChar(tt.mask); got != tt.want {
+			t.Errorf("brailleChar(%#x) = %q, want %q", tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate_CompactBraille(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "あ", Options{Compact: CompactBraille})
+	lines := strings.Split(result, "\n")
+	if len(lines) != 2 {
+		t.Errorf("CompactBraille height = %d, want 2", len(lines))
+	}
+	for _, r := range result {
+		if r != ' ' && r != '\n' && (r < 0x2800 || r > 0x28FF) {
+			t.Fatalf("CompactBraille output contains non-Braille rune %q", r)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package banner
+
+import (
+	"math"
+
+	mcolor "github.com/qraqras/misaki-banner/internal/color"
+)
+
+// GradientDirection selects the axis along which the gradient changes.
+type GradientDirection string
+
+const (
+	GradientHorizontal GradientDirection = ""         // left to right
+	GradientVertical   GradientDirection = "vertical" // top to bottom
+	GradientDiagonal   GradientDirection = "diagonal" // top-left to bottom-right
+	GradientRadial     GradientDirection = "radial"   // center to corners
+)
+
+// gradient describes how colors vary across a grid.
+type gradient struct {
+	enabled       bool
+	stops         []mcolor.RGB // custom color stops; nil uses the hue/lightness shift
+	dir           GradientDirection
+	height, width int     // size of the grid the gradient spans
+	phase         float64 // shift along the gradient; see cycled
+}
+
+// at returns the color of the pixel at (y, x) for the given base color.
+func (g gradient) at(base mcolor.RGB, y, x int) mcolor.RGB {
+	if !g.enabled {
+		// Single color mode
+		return base
+	}
+
+	t, ok := g.position(y, x)
+	if g.phase != 0 {
+		t += g.phase
+		t -= math.Floor(t)
+		if len(g.stops) == 0 {
+			// The hue shift does not wrap around, so run it there and back
+			t = 1 - math.Abs(2*t-1)
+		}
+	}
+	if len(g.stops) > 0 {
+		return mcolor.Interpolate(g.stops, t)
+	}
+	if !ok {
+		return base
+	}
+
+	// Create a natural gradient by shifting hue and lightness
+	// Hue: Start +20 -> Center 0 -> End -20 (degrees)
+	// Lightness: Start +0.2 -> Center 0 -> End +0.2 (V-shape, factor 0-1)
+	hueDelta := 20.0 - (40.0 * t)       // +20 to -20
+	lightDelta := 0.2 * math.Abs(t-0.5) // +0.2 to 0 to +0.2
+	return mcolor.ShiftColor(base, hueDelta, lightDelta)
+}
+
+// cycled returns a copy of g shifted by phase, with the first stop appended
+// so the gradient wraps around without a seam. Without stops the hue shift
+// is traversed forth and back instead.
+func (g gradient) cycled(phase float64) gradient {
+	g.phase = phase
+	if len(g.stops) > 0 {
+		g.stops = append(g.stops[:len(g.stops):len(g.stops)], g.stops[0])
+	}
+	return g
+}
+
+// position returns the normalized position [0, 1] of (y, x) along the
+// gradient direction. It returns false if the grid has no extent in that
+// direction.
+func (g gradient) position(y, x int) (float64, bool) {
+	// ratio normalizes v to [0, 1] over n cells
+	ratio := func(v, n int) (float64, bool) {
+		if n <= 1 {
+			return 0, false
+		}
+		return math.Max(0, math.Min(1, float64(v)/float64(n-1))), true
+	}
+
+	switch g.dir {
+	case GradientVertical:
+		return ratio(y, g.height)
+	case GradientDiagonal:
+		ty, okY := ratio(y, g.height)
+		tx, okX := ratio(x, g.width)
+		return (ty + tx) / 2, okY || okX
+	case GradientRadial:
+		cy, cx := float64(g.height-1)/2, float64(g.width-1)/2
+		maxDist := math.Hypot(cy, cx)
+		if maxDist == 0 {
+			return 0, false
+		}
+		d := math.Hypot(float64(y)-cy, float64(x)-cx)
+		return math.Min(1, d/maxDist), true
+	default:
+		return ratio(x, g.width)
+	}
+}
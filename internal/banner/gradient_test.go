@@ -0,0 +1,129 @@
+package banner
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+
+	mcolor "github.com/qraqras/misaki-banner/internal/color"
+)
+
+func TestGradientPosition(t *testing.T) {
+	tests := []struct {
+		dir  GradientDirection
+		y, x int
+		want float64
+	}{
+		{GradientHorizontal, 0, 0, 0},
+		{GradientHorizontal, 4, 10, 1},
+		{GradientVertical, 4, 0, 1},
+		{GradientVertical, 0, 10, 0},
+		{GradientDiagonal, 4, 10, 1},
+		{GradientDiagonal, 0, 10, 0.5},
+		{GradientRadial, 2, 5, 0},
+		{GradientRadial, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		g := gradient{enabled: true, dir: tt.dir, height: 5, width: 11}
+		got, _ := g.position(tt.y, tt.x)
+		if got != tt.want {
+			t.Errorf("%q position(%d, %d) = %v, want %v", tt.dir, tt.y, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestGradientAt_Stops(t *testing.T) {
+	g := gradient{
+		enabled: true,
+		stops:   []mcolor.RGB{{R: 255}, {B: 255}},
+		width:   3,
+		height:  1,
+	}
+	if got := g.at(mcolor.RGB{}, 0, 0); got != (mcolor.RGB{R: 255}) {
+		t.Errorf("at(0, 0) = %v, want first stop", got)
+	}
+	if got := g.at(mcolor.RGB{}, 0, 2); got != (mcolor.RGB{B: 255}) {
+		t.Errorf("at(0, 2) = %v, want last stop", got)
+	}
+}
+
+func TestGradientAt_Cycled(t *testing.T) {
+	g := gradient{
+		enabled: true,
+		stops:   []mcolor.RGB{{R: 255}, {B: 255}},
+		width:   5,
+		height:  1,
+	}.cycled(0.5)
+	// Halfway along the loop red -> blue -> red
+	if got := g.at(mcolor.RGB{}, 0, 0); got != (mcolor.RGB{B: 255}) {
+		t.Errorf("at(0, 0) = %v, want the stop halfway along", got)
+	}
+	if got := g.at(mcolor.RGB{}, 0, 2); got != (mcolor.RGB{R: 255}) {
+		t.Errorf("at(0, 2) = %v, want the first stop wrapped around", got)
+	}
+	if len(g.stops) != 3 {
+		t.Errorf("cycled stops = %v, want the first stop appended", g.stops)
+	}
+}
+
+func TestGradientAt_CycledWithoutStops(t *testing.T) {
+	base := mcolor.RGB{R: 0, G: 200, B: 200}
+	g := gradient{enabled: true, width: 101, height: 1}.cycled(0.3)
+
+	// Neighbouring dots stay close everywhere, including where t wraps
+	diff := func(a, b mcolor.RGB) int {
+		d := 0
+		for _, v := range []int{int(a.R) - int(b.R), int(a.G) - int(b.G), int(a.B) - int(b.B)} {
+			d = max(d, v, -v)
+		}
+		return d
+	}
+	for x := 1; x < 101; x++ {
+		if d := diff(g.at(base, 0, x-1), g.at(base, 0, x)); d > 10 {
+			t.Errorf("colors at %d and %d differ by %d, want a seamless cycle", x-1, x, d)
+		}
+	}
+	if g.at(base, 0, 0) != g.cycled(1.3).at(base, 0, 0) {
+		t.Error("a full cycle should return to the same colors")
+	}
+}
+
+func TestGradientAt_Disabled(t *testing.T) {
+	base := mcolor.RGB{R: 10, G: 20, B: 30}
+	g := gradient{width: 10, height: 10}
+	if got := g.at(base, 3, 3); got != base {
+		t.Errorf("at() with gradient disabled = %v, want %v", got, base)
+	}
+}
+
+func TestGenerate_GradientColors(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "ABC", Options{GradientColors: "ff0000,0000ff"})
+	seqs := regexp.MustCompile(`\033\[38;2;(\d+);\d+;(\d+)m`).FindAllStringSubmatch(result, -1)
+	if len(seqs) == 0 {
+		t.Fatal("GradientColors output does not contain ANSI escape sequences")
+	}
+
+	// Reds fade into blues from left to right
+	redness := func(m []string) int {
+		r, _ := strconv.Atoi(m[1])
+		b, _ := strconv.Atoi(m[2])
+		return r - b
+	}
+	minRed, maxRed := 255, -255
+	for _, m := range seqs {
+		minRed, maxRed = min(minRed, redness(m)), max(maxRed, redness(m))
+	}
+	if maxRed < 100 || minRed > -100 {
+		t.Errorf("GradientColors output spans redness %d..%d, want red to blue", minRed, maxRed)
+	}
+}
+
+func TestGenerate_Palette(t *testing.T) {
+	face := newTestFace(t)
+	withPalette := Generate(face, "ABC", Options{Palette: "ocean"})
+	withStops := Generate(face, "ABC", Options{GradientColors: "00b4db,0083b0,003c8f"})
+	if withPalette != withStops {
+		t.Error("Palette output differs from the equivalent GradientColors output")
+	}
+}
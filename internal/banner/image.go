@@ -0,0 +1,182 @@
+package banner
+
+import (
+	"image"
+	"image/color"
+
+	mcolor "github.com/qraqras/misaki-banner/internal/color"
+	mfont "github.com/qraqras/misaki-banner/internal/font"
+)
+
+// DefaultDotSize is the default number of image pixels per dot.
+const DefaultDotSize = 8
+
+// defaultImageColor is the dot color used when no text color is set.
+// Terminals fall back to their own foreground color; images have none.
+var defaultImageColor = mcolor.RGB{R: 0, G: 0, B: 0}
+
+// Opacity of text and shadow dots per shadow mode, mirroring the terminal
+// look: outline draws solid text with a lighter shadow, solid draws
+// light-shaded (░) text with a full-block shadow, and glow draws solid
+// text with a lighter border.
+const (
+	opaque     = 255
+	halfOpaque = 128
+	lightShade = 96
+)
+
+// dot is a single rasterized cell of the banner.
+type dot struct {
+	color  mcolor.RGB
+	alpha  uint8 // 0 means the cell is transparent
+	shadow bool  // the cell belongs to the shadow rather than the text
+}
+
+// GenerateImage rasterizes the banner for the given text into an image,
+// drawing every dot as a dotSize×dotSize square on a transparent background.
+// Lines are aligned and stacked with the line gap of Generate, one dot per line.
+// Text without lit dots yields a single transparent pixel, since image
+// encoders reject an empty image.
+func GenerateImage(face *mfont.Face, text string, opts Options, dotSize int) *image.NRGBA {
+	if dotSize < 1 {
+		dotSize = 1
+	}
+
+	rows, width := rasterText(face, text, opts)
+
+	img := image.NewNRGBA(image.Rect(0, 0, max(width*dotSize, 1), max(len(rows)*dotSize, 1)))
+	for y, row := range rows {
+		for x, d := range row {
+			if d.alpha == 0 {
+				continue
+			}
+			c := color.NRGBA{R: d.color.R, G: d.color.G, B: d.color.B, A: d.alpha}
+			for py := y * dotSize; py < (y+1)*dotSize; py++ {
+				for px := x * dotSize; px < (x+1)*dotSize; px++ {
+					img.SetNRGBA(px, py, c)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// rasterText rasterizes every block of text, aligns them and stacks them
+// with the line gap in between. It returns the rows and the width of the
+// widest row.
+func rasterText(face *mfont.Face, text string, opts Options) ([][]dot, int) {
+	var blocks [][][]dot
+	width := 0
+	for _, grid := range layoutText(face, text, opts) {
+		dots := rasterGrid(grid, opts)
+		if dots == nil {
+			continue
+		}
+		blocks = append(blocks, dots)
+		for _, row := range dots {
+			width = max(width, len(row))
+		}
+	}
+
+	var rows [][]dot
+	for i, block := range blocks {
+		if i > 0 {
+			for j := 0; j < opts.lineGap(); j++ {
+				rows = append(rows, nil) // blank separator row
+			}
+		}
+		blockWidth := 0
+		for _, row := range block {
+			blockWidth = max(blockWidth, len(row))
+		}
+		offset := alignOffset(opts.Align, blockWidth, width)
+		for _, row := range block {
+			if offset > 0 {
+				row = append(make([]dot, offset), row...)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, width
+}
+
+// rasterGrid converts a glyph grid into rows of dots,
+// with blank rows at the top and bottom trimmed unless opts.keepsBlankRows.
+// It returns nil if the grid renders nothing.
+func rasterGrid(grid [][]bool, opts Options) [][]dot {
+	h, w := len(grid), len(grid[0])
+
+	ci := parseColorInfo(opts).spanning(h, w)
+	if !ci.hasColor {
+		ci.color = defaultImageColor
+	}
+
+	isOn := func(y, x int) bool {
+		if y < 0 || y >= h || x < 0 || x >= w {
+			return false
+		}
+		return grid[y][x]
+	}
+
+	sh := shadowSpecOf(opts)
+	textAlpha, shadowAlpha := uint8(opaque), uint8(0)
+	outH, outW, oy, ox := h, w, 0, 0
+	switch opts.Shadow {
+	case ShadowOutline, ShadowGlow:
+		shadowAlpha = halfOpaque
+		outH, outW, oy, ox = sh.canvas(h, w)
+	case ShadowSolid:
+		textAlpha, shadowAlpha = lightShade, opaque
+		outH, outW, oy, ox = sh.canvas(h, w)
+	}
+
+	rows := make([][]dot, outH)
+	for cy := 0; cy < outH; cy++ {
+		rows[cy] = make([]dot, outW)
+		for cx := 0; cx < outW; cx++ {
+			y, x := cy-oy, cx-ox
+			if isOn(y, x) {
+				rows[cy][cx] = dot{color: ci.at(y, x), alpha: textAlpha}
+				continue
+			}
+			if shadowAlpha == 0 {
+				continue
+			}
+			if kind, sy, sx := sh.at(isOn, y, x); kind != shadowNone {
+				c := ci.at(sy, sx)
+				if ci.shadow.ok {
+					c = ci.shadow.at(sy, sx)
+				}
+				rows[cy][cx] = dot{color: c, alpha: shadowAlpha, shadow: true}
+			}
+		}
+	}
+
+	if opts.keepsBlankRows() {
+		return rows
+	}
+	return trimBlankDotRows(rows)
+}
+
+// trimBlankDotRows removes fully transparent rows from both ends.
+// It returns nil if every row is blank.
+func trimBlankDotRows(rows [][]dot) [][]dot {
+	blank := func(row []dot) bool {
+		for _, d := range row {
+			if d.alpha != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	for len(rows) > 0 && blank(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	for len(rows) > 0 && blank(rows[0]) {
+		rows = rows[1:]
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows
+}
@@ -0,0 +1,111 @@
+package banner
+
+import (
+	"image/png"
+	"io"
+	"testing"
+)
+
+func TestGenerateImage_Size(t *testing.T) {
+	face := newTestFace(t)
+	dots, _ := rasterText(face, "A", Options{})
+	if dots == nil {
+		t.Fatal("rasterText(\"A\") returned nil")
+	}
+
+	img := GenerateImage(face, "A", Options{}, 4)
+	b := img.Bounds()
+	if b.Dy() != len(dots)*4 || b.Dx() != len(dots[0])*4 {
+		t.Errorf("GenerateImage size = %dx%d, want %dx%d", b.Dx(), b.Dy(), len(dots[0])*4, len(dots)*4)
+	}
+}
+
+func TestGenerateImage_Empty(t *testing.T) {
+	face := newTestFace(t)
+	for _, text := range []string{"", " "} {
+		img := GenerateImage(face, text, Options{}, 4)
+		if b := img.Bounds(); b.Dx() != 1 || b.Dy() != 1 {
+			t.Errorf("GenerateImage(%q) bounds = %v, want a single pixel", text, b)
+		}
+		if img.Pix[3] != 0 {
+			t.Errorf("GenerateImage(%q) pixel is not transparent", text)
+		}
+		if err := png.Encode(io.Discard, img); err != nil {
+			t.Errorf("png.Encode(GenerateImage(%q)) failed: %v", text, err)
+		}
+	}
+}
+
+func TestGenerateImage_Color(t *testing.T) {
+	face := newTestFace(t)
+	img := GenerateImage(face, "A", Options{Color: "ff0000"}, 1)
+	found := false
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i+3] == 0 {
+			continue
+		}
+		if img.Pix[i] != 255 || img.Pix[i+1] != 0 || img.Pix[i+2] != 0 {
+			t.Fatalf("pixel color = %v, want ff0000", img.Pix[i:i+3])
+		}
+		found = true
+	}
+	if !found {
+		t.Error("GenerateImage(\"A\") has no opaque pixels")
+	}
+}
+
+func TestGenerateImage_ShadowOutline(t *testing.T) {
+	face := newTestFace(t)
+	img := GenerateImage(face, "A", Options{Shadow: ShadowOutline}, 1)
+	hasShadow := false
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] == halfOpaque {
+			hasShadow = true
+			break
+		}
+	}
+	if !hasShadow {
+		t.Error("GenerateImage with ShadowOutline has no shadow pixels")
+	}
+}
+
+func TestRasterText_ShadowColor(t *testing.T) {
+	face := newTestFace(t)
+	dots, _ := rasterText(face, "A", Options{Color: "ff0000", Shadow: ShadowOutline, ShadowColor: "0000ff"})
+	var text, shadow int
+	for _, row := range dots {
+		for _, d := range row {
+			switch {
+			case d.alpha == 0:
+			case d.shadow && d.color.B == 255 && d.color.R == 0:
+				shadow++
+			case !d.shadow && d.color.R == 255 && d.color.B == 0:
+				text++
+			default:
+				t.Fatalf("dot %+v has neither the text nor the shadow color", d)
+			}
+		}
+	}
+	if text == 0 || shadow == 0 {
+		t.Errorf("rasterText has %d text and %d shadow dots, want both", text, shadow)
+	}
+}
+
+func TestGenerateImage_MultiLine(t *testing.T) {
+	face := newTestFace(t)
+	single := GenerateImage(face, "A", Options{}, 1)
+	multi := GenerateImage(face, "A\nA", Options{}, 1)
+	want := single.Bounds().Dy()*2 + 1
+	if multi.Bounds().Dy() != want {
+		t.Errorf("GenerateImage(\"A\\nA\") height = %d, want %d", multi.Bounds().Dy(), want)
+	}
+}
+
+func TestRasterText_Glow(t *testing.T) {
+	face := newTestFace(t)
+	plain, plainW := rasterText(face, "A", Options{})
+	glow, glowW := rasterText(face, "A", Options{Shadow: ShadowGlow})
+	if glowW <= plainW || len(glow) <= len(plain) {
+		t.Errorf("glow raster is %dx%d, want larger than %dx%d", glowW, len(glow), plainW, len(plain))
+	}
+}
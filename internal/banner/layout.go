@@ -0,0 +1,105 @@
+package banner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Alignment selects how blocks of a multi-line banner line up.
+type Alignment string
+
+const (
+	AlignLeft   Alignment = ""       // flush left
+	AlignCenter Alignment = "center" // centered
+	AlignRight  Alignment = "right"  // flush right
+)
+
+// alignOffset returns how far to shift a block of the given width within
+// the target width.
+func alignOffset(align Alignment, width, target int) int {
+	switch align {
+	case AlignCenter:
+		return max(target-width, 0) / 2
+	case AlignRight:
+		return max(target-width, 0)
+	default:
+		return 0
+	}
+}
+
+// lineGap returns the number of blank lines between blocks.
+func (o Options) lineGap() int {
+	switch {
+	case o.LineSpacing < 0:
+		return 0
+	case o.LineSpacing == 0:
+		return 1
+	default:
+		return o.LineSpacing
+	}
+}
+
+// padGrid surrounds grid with n empty dots on every side.
+func padGrid(grid [][]bool, n int) [][]bool {
+	if n <= 0 {
+		return grid
+	}
+	h, w := len(grid), len(grid[0])
+	out := make([][]bool, h+2*n)
+	for y := range out {
+		out[y] = make([]bool, w+2*n)
+		if y >= n && y < h+n {
+			copy(out[y][n:], grid[y-n])
+		}
+	}
+	return out
+}
+
+// ansiEscape matches the SGR escape sequences the renderers emit.
+var ansiEscape = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// VisibleWidth returns the terminal columns of s, ignoring the escape
+// sequences of colored output.
+func VisibleWidth(s string) int {
+	return displayWidth(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// joinBlocks aligns the rendered blocks, separates them by the line gap
+// and surrounds the result with the margin.
+func joinBlocks(blocks [][]string, opts Options) string {
+	if len(blocks) == 0 {
+		return ""
+	}
+	widths := make([]int, len(blocks))
+	target := 0
+	for i, lines := range blocks {
+		for _, line := range lines {
+			widths[i] = max(widths[i], VisibleWidth(line))
+		}
+		target = max(target, widths[i])
+	}
+	if opts.Width > 0 {
+		target = max(target, opts.Width-2*max(opts.Margin, 0))
+	}
+
+	margin := max(opts.Margin, 0)
+	var out []string
+	for i := 0; i < margin; i++ {
+		out = append(out, "")
+	}
+	for i, lines := range blocks {
+		if i > 0 {
+			for j := 0; j < opts.lineGap(); j++ {
+				out = append(out, "")
+			}
+		}
+		indent := strings.Repeat(" ", margin+alignOffset(opts.Align, widths[i], target))
+		for _, line := range lines {
+			out = append(out, indent+line)
+		}
+	}
+	for i := 0; i < margin; i++ {
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
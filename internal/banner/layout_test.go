@@ -0,0 +1,81 @@
+package banner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJoinBlocks(t *testing.T) {
+	blocks := [][]string{{"####"}, {"##"}}
+	tests := []struct {
+		opts Options
+		want string
+	}{
+		{Options{}, "####\n\n##"},
+		{Options{Align: AlignCenter}, "####\n\n ##"},
+		{Options{Align: AlignRight}, "####\n\n  ##"},
+		{Options{Align: AlignRight, Width: 8}, "    ####\n\n      ##"},
+		{Options{LineSpacing: -1}, "####\n##"},
+		{Options{LineSpacing: 2}, "####\n\n\n##"},
+		{Options{Margin: 1}, "\n ####\n\n ##\n"},
+	}
+	for _, tt := range tests {
+		if got := joinBlocks(blocks, tt.opts); got != tt.want {
+			t.Errorf("%+v joinBlocks() = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+	if got := VisibleWidth("\033[38;2;1;2;3m██\033[0m  "); got != 4 {
+		t.Errorf("VisibleWidth() = %d, want 4", got)
+	}
+}
+
+func TestPadGrid(t *testing.T) {
+	checkGrid(t, "padGrid", padGrid([][]bool{{true}}, 1), []string{
+		"...",
+		".#.",
+		"...",
+	})
+}
+
+func TestGenerate_AlignCenter(t *testing.T) {
+	face := newTestFace(t)
+	left := strings.Split(Generate(face, "ABCD\nA", Options{}), "\n")
+	center := strings.Split(Generate(face, "ABCD\nA", Options{Align: AlignCenter}), "\n")
+	last := len(center) - 1
+	if center[0] != left[0] {
+		t.Error("the widest line should not move when centered")
+	}
+	indent := len(center[last]) - len(strings.TrimLeft(center[last], " "))
+	if indent <= len(left[last])-len(strings.TrimLeft(left[last], " ")) {
+		t.Errorf("centered short line %q is not indented", center[last])
+	}
+}
+
+func TestGenerate_PaddingBackground(t *testing.T) {
+	face := newTestFace(t)
+	plain := strings.Split(Generate(face, "A", Options{Background: "navy"}), "\n")
+	padded := strings.Split(Generate(face, "A", Options{Background: "navy", Padding: 2}), "\n")
+	if len(padded) != len(plain)+4 {
+		t.Errorf("padded banner has %d lines, want %d", len(padded), len(plain)+4)
+	}
+}
+
+func TestRasterText_AlignSpacing(t *testing.T) {
+	face := newTestFace(t)
+	rows, width := rasterText(face, "ABC\nA", Options{Align: AlignRight, LineSpacing: 3})
+	gap := 0
+	for _, row := range rows {
+		if row == nil {
+			gap++
+		}
+	}
+	if gap != 3 {
+		t.Errorf("rasterText has %d separator rows, want 3", gap)
+	}
+	if last := rows[len(rows)-1]; len(last) != width {
+		t.Errorf("right-aligned last row is %d dots wide, want %d", len(last), width)
+	}
+}
@@ -0,0 +1,66 @@
+package banner
+
+// scaleGrid upsamples grid by sx horizontally and sy vertically. With smooth
+// set, every factor of two shared by both axes is applied with Scale2x (EPX)
+// so diagonals stay smooth; any remaining factor repeats dots.
+func scaleGrid(grid [][]bool, sx, sy int, smooth bool) [][]bool {
+	sx, sy = max(sx, 1), max(sy, 1)
+	if smooth {
+		for sx%2 == 0 && sy%2 == 0 {
+			grid = scale2x(grid)
+			sx, sy = sx/2, sy/2
+		}
+	}
+	if sx == 1 && sy == 1 {
+		return grid
+	}
+
+	h, w := len(grid), len(grid[0])
+	out := make([][]bool, h*sy)
+	for y := range out {
+		out[y] = make([]bool, w*sx)
+		for x := range out[y] {
+			out[y][x] = grid[y/sy][x/sx]
+		}
+	}
+	return out
+}
+
+// scale2x doubles grid with the Scale2x (EPX) algorithm, which fills in the
+// corners where two lit edges meet instead of repeating a staircase.
+func scale2x(grid [][]bool) [][]bool {
+	h, w := len(grid), len(grid[0])
+	// at returns the dot at (y, x), replicating the edges outside the grid
+	at := func(y, x int) bool {
+		return grid[min(max(y, 0), h-1)][min(max(x, 0), w-1)]
+	}
+
+	out := make([][]bool, h*2)
+	for y := range out {
+		out[y] = make([]bool, w*2)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			p := grid[y][x]
+			a, b := at(y-1, x), at(y, x+1) // above, right
+			c, d := at(y, x-1), at(y+1, x) // left, below
+
+			e0, e1, e2, e3 := p, p, p, p
+			if c == a && c != d && a != b {
+				e0 = a
+			}
+			if a == b && a != c && b != d {
+				e1 = b
+			}
+			if d == c && d != b && c != a {
+				e2 = c
+			}
+			if b == d && b != a && d != c {
+				e3 = d
+			}
+			out[2*y][2*x], out[2*y][2*x+1] = e0, e1
+			out[2*y+1][2*x], out[2*y+1][2*x+1] = e2, e3
+		}
+	}
+	return out
+}
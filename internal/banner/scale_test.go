@@ -0,0 +1,101 @@
+package banner
+
+import (
+	"errors"
+	"testing"
+)
+
+func gridString(grid [][]bool) []string {
+	rows := make([]string, len(grid))
+	for y, row := range grid {
+		b := make([]byte, len(row))
+		for x, on := range row {
+			b[x] = '.'
+			if on {
+				b[x] = '#'
+			}
+		}
+		rows[y] = string(b)
+	}
+	return rows
+}
+
+func checkGrid(t *testing.T, name string, got [][]bool, want []string) {
+	t.Helper()
+	rows := gridString(got)
+	if len(rows) != len(want) {
+		t.Fatalf("%s = %q, want %q", name, rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("%s = %q, want %q", name, rows, want)
+			return
+		}
+	}
+}
+
+func TestScaleGrid(t *testing.T) {
+	grid := [][]bool{
+		{true, false},
+		{false, true},
+	}
+	checkGrid(t, "scaleGrid(3, 2)", scaleGrid(grid, 3, 2, false), []string{
+		"###...",
+		"###...",
+		"...###",
+		"...###",
+	})
+	checkGrid(t, "scaleGrid(1, 1)", scaleGrid(grid, 0, 1, false), []string{
+		"#.",
+		".#",
+	})
+}
+
+func TestScale2x(t *testing.T) {
+	// The inner corner of an L shape is filled in instead of left stepped
+	grid := [][]bool{
+		{false, true},
+		{true, true},
+	}
+	checkGrid(t, "scale2x", scale2x(grid), []string{
+		"..##",
+		".###",
+		"####",
+		"####",
+	})
+	checkGrid(t, "scaleGrid(2, 2)", scaleGrid(grid, 2, 2, false), []string{
+		"..##",
+		"..##",
+		"####",
+		"####",
+	})
+}
+
+func TestScaleGrid_SmoothRemainder(t *testing.T) {
+	grid := [][]bool{{true}}
+	// 4x smoothing of a single dot is two Scale2x passes of a solid block
+	if got := scaleGrid(grid, 4, 4, true); len(got) != 4 || len(got[0]) != 4 {
+		t.Errorf("scaleGrid(4, 4, smooth) is %dx%d, want 4x4", len(got[0]), len(got))
+	}
+	// Mismatched axes cannot share a Scale2x pass
+	if got := scaleGrid(grid, 2, 3, true); len(got) != 3 || len(got[0]) != 2 {
+		t.Errorf("scaleGrid(2, 3, smooth) is %dx%d, want 2x3", len(got[0]), len(got))
+	}
+}
+
+func TestGenerate_Scale(t *testing.T) {
+	face := newTestFace(t)
+	plain, _ := rasterText(face, "A", Options{})
+	scaled, _ := rasterText(face, "A", Options{ScaleX: 2, ScaleY: 3})
+	if len(scaled[0]) != 2*len(plain[0]) {
+		t.Errorf("scaled width = %d, want %d", len(scaled[0]), 2*len(plain[0]))
+	}
+	if len(scaled) < 3*len(plain)-2 {
+		t.Errorf("scaled height = %d, want about %d", len(scaled), 3*len(plain))
+	}
+
+	var optErr *OptionError
+	if err := (Options{ScaleX: -1}).Validate(); !errors.As(err, &optErr) || optErr.Option != "ScaleX" {
+		t.Errorf("Validate() = %v, want ScaleX OptionError", err)
+	}
+}
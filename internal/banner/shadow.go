@@ -0,0 +1,116 @@
+package banner
+
+// ShadowDirection selects which way the shadow falls from the text.
+type ShadowDirection string
+
+const (
+	ShadowDirDownRight ShadowDirection = ""          // toward the bottom-right
+	ShadowDirDown      ShadowDirection = "down"      // straight down
+	ShadowDirDownLeft  ShadowDirection = "down-left" // toward the bottom-left
+	ShadowDirLeft      ShadowDirection = "left"      // to the left
+	ShadowDirUpLeft    ShadowDirection = "up-left"   // toward the top-left
+	ShadowDirUp        ShadowDirection = "up"        // straight up
+	ShadowDirUpRight   ShadowDirection = "up-right"  // toward the top-right
+	ShadowDirRight     ShadowDirection = "right"     // to the right
+)
+
+// shadowOffsets maps each direction to its (dy, dx) step.
+var shadowOffsets = map[ShadowDirection][2]int{
+	ShadowDirDownRight: {1, 1},
+	ShadowDirDown:      {1, 0},
+	ShadowDirDownLeft:  {1, -1},
+	ShadowDirLeft:      {0, -1},
+	ShadowDirUpLeft:    {-1, -1},
+	ShadowDirUp:        {-1, 0},
+	ShadowDirUpRight:   {-1, 1},
+	ShadowDirRight:     {0, 1},
+}
+
+// shadowSpec is the geometry of a shadow: a step direction and the number
+// of cells the text is extruded along it. A glow instead surrounds the text
+// on all sides, depth cells wide.
+type shadowSpec struct {
+	dy, dx, depth int
+	glow          bool
+}
+
+// shadowSpecOf returns the shadow geometry of opts. An unknown direction
+// falls back to the bottom-right and a depth below 1 to 1.
+func shadowSpecOf(opts Options) shadowSpec {
+	off, ok := shadowOffsets[opts.ShadowDir]
+	if !ok {
+		off = shadowOffsets[ShadowDirDownRight]
+	}
+	depth := max(opts.ShadowDepth, 1)
+	if opts.Shadow == ShadowGlow {
+		return shadowSpec{depth: depth, glow: true}
+	}
+	return shadowSpec{dy: off[0], dx: off[1], depth: depth}
+}
+
+// classic reports whether s is the 1-cell bottom-right shadow drawn with
+// the box-drawing characters of the shadow mode.
+func (s shadowSpec) classic() bool {
+	return s == shadowSpec{dy: 1, dx: 1, depth: 1}
+}
+
+// canvas returns the size of the canvas that fits an h×w grid and its
+// shadow, and the offset of the grid within it.
+func (s shadowSpec) canvas(h, w int) (outH, outW, oy, ox int) {
+	if s.glow {
+		return h + 2*s.depth, w + 2*s.depth, s.depth, s.depth
+	}
+	outH, outW = h+s.depth*abs(s.dy), w+s.depth*abs(s.dx)
+	if s.dy < 0 {
+		oy = s.depth
+	}
+	if s.dx < 0 {
+		ox = s.depth
+	}
+	return outH, outW, oy, ox
+}
+
+// at classifies the empty cell at (y, x). The classic shadow uses shadowAt;
+// otherwise the cell is extruded if a text pixel lies up to depth steps
+// against the shadow direction, and the nearest such pixel casts it.
+// A glow cell is any cell within depth of a text pixel in the 8-neighbourhood.
+func (s shadowSpec) at(isOn func(y, x int) bool, y, x int) (shadowKind, int, int) {
+	if s.glow {
+		return glowAt(isOn, y, x, s.depth)
+	}
+	if s.classic() {
+		return shadowAt(isOn, y, x)
+	}
+	for k := 1; k <= s.depth; k++ {
+		sy, sx := y-k*s.dy, x-k*s.dx
+		if isOn(sy, sx) {
+			return shadowExtrude, sy, sx
+		}
+	}
+	return shadowNone, y, x
+}
+
+// glowAt searches the rings around (y, x) outwards up to radius and returns
+// the first text pixel found.
+func glowAt(isOn func(y, x int) bool, y, x, radius int) (shadowKind, int, int) {
+	for k := 1; k <= radius; k++ {
+		for dy := -k; dy <= k; dy++ {
+			for dx := -k; dx <= k; dx++ {
+				if max(abs(dy), abs(dx)) != k {
+					continue
+				}
+				if isOn(y+dy, x+dx) {
+					return shadowGlow, y + dy, x + dx
+				}
+			}
+		}
+	}
+	return shadowNone, y, x
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
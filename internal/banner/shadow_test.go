@@ -0,0 +1,139 @@
+package banner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestShadowSpec_At(t *testing.T) {
+	// A single lit pixel at (2, 2) on a 5x5 grid
+	isOn := func(y, x int) bool { return y == 2 && x == 2 }
+
+	tests := []struct {
+		dir   ShadowDirection
+		depth int
+		y, x  int
+		want  shadowKind
+	}{
+		{ShadowDirDown, 1, 3, 2, shadowExtrude},
+		{ShadowDirDown, 1, 4, 2, shadowNone},
+		{ShadowDirDown, 2, 4, 2, shadowExtrude},
+		{ShadowDirUpLeft, 3, 0, 0, shadowExtrude},
+		{ShadowDirUpLeft, 3, 3, 3, shadowNone},
+		{ShadowDirRight, 2, 2, 4, shadowExtrude},
+		{ShadowDirLeft, 2, 2, 4, shadowNone},
+		{ShadowDirDownRight, 1, 3, 3, shadowDiag},
+	}
+	for _, tt := range tests {
+		sh := shadowSpecOf(Options{ShadowDir: tt.dir, ShadowDepth: tt.depth})
+		kind, sy, sx := sh.at(isOn, tt.y, tt.x)
+		if kind != tt.want {
+			t.Errorf("%q depth %d at(%d, %d) = %v, want %v", tt.dir, tt.depth, tt.y, tt.x, kind, tt.want)
+		}
+		if kind != shadowNone && (sy != 2 || sx != 2) {
+			t.Errorf("%q depth %d at(%d, %d) caster = (%d, %d), want (2, 2)", tt.dir, tt.depth, tt.y, tt.x, sy, sx)
+		}
+	}
+}
+
+func TestShadowSpec_Canvas(t *testing.T) {
+	tests := []struct {
+		dir                    ShadowDirection
+		depth                  int
+		outH, outW, offY, offX int
+	}{
+		{ShadowDirDownRight, 1, 9, 11, 0, 0},
+		{ShadowDirUpLeft, 2, 10, 12, 2, 2},
+		{ShadowDirRight, 3, 8, 13, 0, 0},
+		{ShadowDirUp, 3, 11, 10, 3, 0},
+	}
+	for _, tt := range tests {
+		sh := shadowSpecOf(Options{ShadowDir: tt.dir, ShadowDepth: tt.depth})
+		outH, outW, oy, ox := sh.canvas(8, 10)
+		if outH != tt.outH || outW != tt.outW || oy != tt.offY || ox != tt.offX {
+			t.Errorf("%q depth %d canvas(8, 10) = %d, %d, %d, %d, want %d, %d, %d, %d",
+				tt.dir, tt.depth, outH, outW, oy, ox, tt.outH, tt.outW, tt.offY, tt.offX)
+		}
+	}
+}
+
+func TestGenerate_ShadowDefaultDirection(t *testing.T) {
+	face := newTestFace(t)
+	want := Generate(face, "AB", Options{Shadow: ShadowOutline})
+	got := Generate(face, "AB", Options{Shadow: ShadowOutline, ShadowDir: ShadowDirDownRight, ShadowDepth: 1})
+	if got != want {
+		t.Error("explicit bottom-right depth 1 shadow differs from the default")
+	}
+}
+
+func TestGenerate_ShadowExtrude(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "A", Options{Shadow: ShadowSolid, ShadowDir: ShadowDirDown, ShadowDepth: 3})
+	if !strings.Contains(result, "██") || !strings.Contains(result, "░░") {
+		t.Errorf("extruded solid shadow should mix text and shadow blocks:\n%s", result)
+	}
+	if strings.ContainsAny(result, "▀▄") {
+		t.Errorf("extruded shadow should not use the bottom-right edge characters:\n%s", result)
+	}
+
+	plain := Generate(face, "A", Options{})
+	if got, want := strings.Count(result, "\n"), strings.Count(plain, "\n")+3; got < want {
+		t.Errorf("extruded output has %d line breaks, want at least %d", got, want)
+	}
+}
+
+func TestOptionsValidate_Shadow(t *testing.T) {
+	err := Options{ShadowDir: "sideways", ShadowDepth: -1}.Validate()
+	var optErr *OptionError
+	if !errors.As(err, &optErr) || optErr.Option != "ShadowDir" {
+		t.Errorf("Validate() = %v, want ShadowDir OptionError", err)
+	}
+	if !strings.Contains(err.Error(), "ShadowDepth") {
+		t.Errorf("Validate() = %v, want ShadowDepth to be reported", err)
+	}
+}
+
+func TestGlowAt(t *testing.T) {
+	isOn := func(y, x int) bool { return y == 2 && x == 2 }
+	sh := shadowSpecOf(Options{Shadow: ShadowGlow})
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			if isOn(y, x) {
+				continue
+			}
+			kind, _, _ := sh.at(isOn, y, x)
+			near := abs(y-2) <= 1 && abs(x-2) <= 1
+			if (kind == shadowGlow) != near {
+				t.Errorf("glow at(%d, %d) = %v, want glow %v", y, x, kind, near)
+			}
+		}
+	}
+
+	wide := shadowSpecOf(Options{Shadow: ShadowGlow, ShadowDepth: 2})
+	if kind, sy, sx := wide.at(isOn, 0, 4); kind != shadowGlow || sy != 2 || sx != 2 {
+		t.Errorf("radius 2 glow at(0, 4) = %v from (%d, %d), want glow from (2, 2)", kind, sy, sx)
+	}
+	if outH, outW, oy, ox := wide.canvas(8, 10); outH != 12 || outW != 14 || oy != 2 || ox != 2 {
+		t.Errorf("radius 2 glow canvas(8, 10) = %d, %d, %d, %d, want 12, 14, 2, 2", outH, outW, oy, ox)
+	}
+}
+
+func TestGenerate_Glow(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "A", Options{Shadow: ShadowGlow, Color: "c", ShadowColor: "ff00ff"})
+	if !strings.Contains(result, "\033[38;2;255;0;255m░░") {
+		t.Error("glow border should use the shadow color")
+	}
+	if !strings.Contains(result, "\033[38;2;0;255;255m██") {
+		t.Error("glow text should keep the text color")
+	}
+
+	// The border surrounds the text, so the first and last lines are all border
+	plain := strings.Split(Generate(face, "A", Options{Shadow: ShadowGlow}), "\n")
+	for _, line := range []string{plain[0], plain[len(plain)-1]} {
+		if strings.Contains(line, "█") || !strings.Contains(line, "░░") {
+			t.Errorf("glow edge line %q should contain only border characters", line)
+		}
+	}
+}
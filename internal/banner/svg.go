@@ -0,0 +1,92 @@
+package banner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	mcolor "github.com/qraqras/misaki-banner/internal/color"
+	mfont "github.com/qraqras/misaki-banner/internal/font"
+)
+
+// GenerateSVG renders the banner for the given text as an SVG document.
+// Horizontal runs of same-colored dots are merged, and all runs of one
+// color are drawn as a single path. Shadow dots are drawn in their own
+// layer below the text, so gradients become per-dot fills without
+// duplicating geometry.
+func GenerateSVG(face *mfont.Face, text string, opts Options, dotSize int) string {
+	if dotSize < 1 {
+		dotSize = 1
+	}
+
+	rows, width := rasterText(face, text, opts)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width*dotSize, len(rows)*dotSize, width, len(rows))
+	writeSVGLayer(&sb, "shadow", rows, true)
+	writeSVGLayer(&sb, "text", rows, false)
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// svgFill identifies a path by its fill color and opacity.
+type svgFill struct {
+	color mcolor.RGB
+	alpha uint8
+}
+
+// writeSVGLayer writes one <g> element holding the shadow or text dots.
+// Nothing is written if the layer is empty.
+func writeSVGLayer(sb *strings.Builder, id string, rows [][]dot, shadow bool) {
+	paths := make(map[svgFill]*strings.Builder)
+	for y, row := range rows {
+		for x := 0; x < len(row); {
+			d := row[x]
+			if d.alpha == 0 || d.shadow != shadow {
+				x++
+				continue
+			}
+			// Extend the run while the fill stays the same
+			n := 1
+			for x+n < len(row) && row[x+n] == d {
+				n++
+			}
+			key := svgFill{color: d.color, alpha: d.alpha}
+			p, ok := paths[key]
+			if !ok {
+				p = &strings.Builder{}
+				paths[key] = p
+			}
+			fmt.Fprintf(p, "M%d %dh%dv1h-%dz", x, y, n, n)
+			x += n
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+
+	// Sort fills so the output is deterministic
+	fills := make([]svgFill, 0, len(paths))
+	for f := range paths {
+		fills = append(fills, f)
+	}
+	sort.Slice(fills, func(i, j int) bool {
+		a, b := fills[i], fills[j]
+		if a.color.Hex() != b.color.Hex() {
+			return a.color.Hex() < b.color.Hex()
+		}
+		return a.alpha < b.alpha
+	})
+
+	fmt.Fprintf(sb, `<g id="%s">`+"\n", id)
+	for _, f := range fills {
+		if f.alpha == opaque {
+			fmt.Fprintf(sb, `<path fill="%s" d="%s"/>`+"\n", f.color.Hex(), paths[f].String())
+		} else {
+			fmt.Fprintf(sb, `<path fill="%s" fill-opacity="%.3f" d="%s"/>`+"\n",
+				f.color.Hex(), float64(f.alpha)/255, paths[f].String())
+		}
+	}
+	sb.WriteString("</g>\n")
+}
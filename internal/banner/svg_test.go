@@ -0,0 +1,43 @@
+package banner
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSVG_Basic(t *testing.T) {
+	face := newTestFace(t)
+	result := GenerateSVG(face, "A", Options{Color: "ff0000"}, 4)
+	if !strings.HasPrefix(result, "<svg ") {
+		t.Fatalf("GenerateSVG output does not start with <svg: %q", result)
+	}
+	if !strings.Contains(result, `fill="#ff0000"`) {
+		t.Error("GenerateSVG output does not contain the text color")
+	}
+	if strings.Contains(result, `id="shadow"`) {
+		t.Error("GenerateSVG without shadow should not contain a shadow layer")
+	}
+}
+
+func TestGenerateSVG_ShadowLayer(t *testing.T) {
+	face := newTestFace(t)
+	result := GenerateSVG(face, "A", Options{Shadow: ShadowOutline}, 4)
+	shadow := strings.Index(result, `id="shadow"`)
+	text := strings.Index(result, `id="text"`)
+	if shadow < 0 || text < 0 {
+		t.Fatal("GenerateSVG with shadow should contain shadow and text layers")
+	}
+	if shadow > text {
+		t.Error("shadow layer should be drawn before the text layer")
+	}
+}
+
+func TestGenerateSVG_MergedRuns(t *testing.T) {
+	face := newTestFace(t)
+	// 'ー' has a long horizontal stroke that should become a single run
+	result := GenerateSVG(face, "ー", Options{}, 1)
+	if !regexp.MustCompile(`h([2-9]|\d{2,})v1`).MatchString(result) {
+		t.Errorf("GenerateSVG did not merge horizontal runs: %q", result)
+	}
+}
@@ -0,0 +1,172 @@
+package banner
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	mcolor "github.com/qraqras/misaki-banner/internal/color"
+	mfont "github.com/qraqras/misaki-banner/internal/font"
+)
+
+// OptionError reports an invalid Options field.
+type OptionError struct {
+	Option string // field name, e.g. "Color"
+	Value  string // offending value
+	Err    error  // underlying parse error, if any
+}
+
+func (e *OptionError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("invalid %s %q: %v", e.Option, e.Value, e.Err)
+	}
+	return fmt.Sprintf("invalid %s %q", e.Option, e.Value)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// MissingGlyph is a rune the face cannot render and where it appears.
+type MissingGlyph struct {
+	Rune   rune
+	Line   int // 1-based line number
+	Column int // 1-based column, counted in runes
+}
+
+// MissingGlyphError reports every occurrence of runes the face cannot render.
+type MissingGlyphError struct {
+	Glyphs []MissingGlyph
+}
+
+func (e *MissingGlyphError) Error() string {
+	parts := make([]string, len(e.Glyphs))
+	for i, g := range e.Glyphs {
+		parts[i] = fmt.Sprintf("%q (%U) at %d:%d", g.Rune, g.Rune, g.Line, g.Column)
+	}
+	return "no glyph for " + strings.Join(parts, ", ")
+}
+
+// Validate checks that every option holds a valid value.
+// It returns nil, or the *OptionError of each invalid field joined with errors.Join.
+func (o Options) Validate() error {
+	var errs []error
+	if o.Color != "" {
+		if _, err := mcolor.ParseColor(o.Color); err != nil {
+			errs = append(errs, &OptionError{Option: "Color", Value: o.Color, Err: err})
+		}
+	}
+	if o.GradientColors != "" {
+		if _, err := mcolor.ParseColorList(o.GradientColors); err != nil {
+			errs = append(errs, &OptionError{Option: "GradientColors", Value: o.GradientColors, Err: err})
+		}
+	}
+	if o.Palette != "" {
+		if _, ok := mcolor.LookupPalette(o.Palette); !ok {
+			errs = append(errs, &OptionError{
+				Option: "Palette",
+				Value:  o.Palette,
+				Err:    fmt.Errorf("unknown palette (available: %s)", strings.Join(mcolor.PaletteNames(), ", ")),
+			})
+		}
+	}
+	if err := validateFill(o.Background); err != nil {
+		errs = append(errs, &OptionError{Option: "Background", Value: o.Background, Err: err})
+	}
+	if err := validateFill(o.ShadowColor); err != nil {
+		errs = append(errs, &OptionError{Option: "ShadowColor", Value: o.ShadowColor, Err: err})
+	}
+	switch o.GradientDir {
+	case GradientHorizontal, GradientVertical, GradientDiagonal, GradientRadial:
+	default:
+		errs = append(errs, &OptionError{Option: "GradientDir", Value: string(o.GradientDir)})
+	}
+	switch o.Shadow {
+	case ShadowNone, ShadowOutline, ShadowSolid, ShadowGlow:
+	default:
+		errs = append(errs, &OptionError{Option: "Shadow", Value: string(o.Shadow)})
+	}
+	if _, ok := shadowOffsets[o.ShadowDir]; !ok {
+		errs = append(errs, &OptionError{Option: "ShadowDir", Value: string(o.ShadowDir)})
+	}
+	if o.ShadowDepth < 0 {
+		errs = append(errs, &OptionError{Option: "ShadowDepth", Value: strconv.Itoa(o.ShadowDepth)})
+	}
+	if o.CharSet != nil {
+		if err := o.charSet().validate(); err != nil {
+			errs = append(errs, &OptionError{Option: "CharSet", Value: o.CharSet.TextOn, Err: err})
+		}
+	}
+	if o.ScaleX < 0 {
+		errs = append(errs, &OptionError{Option: "ScaleX", Value: strconv.Itoa(o.ScaleX)})
+	}
+	if o.ScaleY < 0 {
+		errs = append(errs, &OptionError{Option: "ScaleY", Value: strconv.Itoa(o.ScaleY)})
+	}
+	switch o.Align {
+	case AlignLeft, AlignCenter, AlignRight:
+	default:
+		errs = append(errs, &OptionError{Option: "Align", Value: string(o.Align)})
+	}
+	if o.Margin < 0 {
+		errs = append(errs, &OptionError{Option: "Margin", Value: strconv.Itoa(o.Margin)})
+	}
+	if o.Padding < 0 {
+		errs = append(errs, &OptionError{Option: "Padding", Value: strconv.Itoa(o.Padding)})
+	}
+	if o.Width < 0 {
+		errs = append(errs, &OptionError{Option: "Width", Value: strconv.Itoa(o.Width)})
+	}
+	if o.Reveal < 0 {
+		errs = append(errs, &OptionError{Option: "Reveal", Value: strconv.Itoa(o.Reveal)})
+	}
+	if o.Viewport < 0 {
+		errs = append(errs, &OptionError{Option: "Viewport", Value: strconv.Itoa(o.Viewport)})
+	}
+	switch o.Compact {
+	case CompactNone, CompactHalf, CompactQuarter, CompactBraille:
+	default:
+		errs = append(errs, &OptionError{Option: "Compact", Value: string(o.Compact)})
+	}
+	return errors.Join(errs...)
+}
+
+// validateFill checks an optional single color or list of gradient stops.
+func validateFill(spec string) error {
+	if spec == "" {
+		return nil
+	}
+	if _, err := mcolor.ParseColor(spec); err == nil {
+		return nil
+	}
+	_, err := mcolor.ParseColorList(spec)
+	return err
+}
+
+// Check validates opts and reports runes of text that face cannot render.
+// It returns nil, or the errors from Validate and a *MissingGlyphError
+// joined with errors.Join.
+func Check(face *mfont.Face, text string, opts Options) error {
+	var errs []error
+	if err := opts.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	var missing []MissingGlyph
+	for i, line := range strings.Split(text, "\n") {
+		col := 0
+		for _, r := range line {
+			col++
+			if unicode.IsControl(r) || face.HasGlyph(r) {
+				continue
+			}
+			missing = append(missing, MissingGlyph{Rune: r, Line: i + 1, Column: col})
+		}
+	}
+	if len(missing) > 0 {
+		errs = append(errs, &MissingGlyphError{Glyphs: missing})
+	}
+	return errors.Join(errs...)
+}
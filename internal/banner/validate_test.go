@@ -0,0 +1,71 @@
+package banner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOptionsValidate_Valid(t *testing.T) {
+	opts := Options{Shadow: ShadowOutline, Color: "c", Compact: CompactHalf}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestOptionsValidate_Invalid(t *testing.T) {
+	opts := Options{Color: "invalid_color", Shadow: "bogus"}
+	err := opts.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+
+	var optErr *OptionError
+	if !errors.As(err, &optErr) {
+		t.Fatalf("Validate() error %v is not an *OptionError", err)
+	}
+	if optErr.Option != "Color" || optErr.Value != "invalid_color" {
+		t.Errorf("OptionError = %+v, want Color invalid_color", optErr)
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("Validate() should report both invalid options, got %v", err)
+	}
+}
+
+func TestOptionsValidate_UnknownPalette(t *testing.T) {
+	err := Options{Palette: "no_such_palette"}.Validate()
+	var optErr *OptionError
+	if !errors.As(err, &optErr) || optErr.Option != "Palette" {
+		t.Errorf("Validate() = %v, want Palette OptionError", err)
+	}
+}
+
+func TestCheck_MissingGlyphs(t *testing.T) {
+	face := newTestFace(t)
+	err := Check(face, "あ😀\nい한", Options{})
+
+	var missErr *MissingGlyphError
+	if !errors.As(err, &missErr) {
+		t.Fatalf("Check() error %v is not a *MissingGlyphError", err)
+	}
+	want := []MissingGlyph{
+		{Rune: '😀', Line: 1, Column: 2},
+		{Rune: '한', Line: 2, Column: 2},
+	}
+	if len(missErr.Glyphs) != len(want) {
+		t.Fatalf("Glyphs = %v, want %v", missErr.Glyphs, want)
+	}
+	for i := range want {
+		if missErr.Glyphs[i] != want[i] {
+			t.Errorf("Glyphs[%d] = %+v, want %+v", i, missErr.Glyphs[i], want[i])
+		}
+	}
+}
+
+func TestCheck_Clean(t *testing.T) {
+	face := newTestFace(t)
+	if err := Check(face, "こんにちは\nHello", Options{Color: "c"}); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,182 @@
+package banner
+
+import (
+	mfont "github.com/qraqras/misaki-banner/internal/font"
+)
+
+// verticalColumnGap is the number of blank dots between columns
+// in vertical mode, in addition to the glyph's own spacing.
+const verticalColumnGap = 1
+
+// verticalForms maps runes to their Unicode vertical presentation forms.
+var verticalForms = map[rune]rune{
+	'、': '︑',
+	'。': '︒',
+	'，': '︐',
+	'：': '︓',
+	'；': '︔',
+	'！': '︕',
+	'？': '︖',
+	'〖': '︗',
+	'〗': '︘',
+	'…': '︙',
+	'‥': '︰',
+	'—': '︱',
+	'–': '︲',
+	'＿': '︳',
+	'（': '︵',
+	'）': '︶',
+	'｛': '︷',
+	'｝': '︸',
+	'〔': '︹',
+	'〕': '︺',
+	'【': '︻',
+	'】': '︼',
+	'《': '︽',
+	'》': '︾',
+	'〈': '︿',
+	'〉': '﹀',
+	'「': '﹁',
+	'」': '﹂',
+	'『': '﹃',
+	'』': '﹄',
+}
+
+// verticalRotated lists runes drawn rotated 90° clockwise in vertical mode
+// when the face has no vertical presentation form for them.
+var verticalRotated = map[rune]bool{
+	'ー': true, '〜': true, '～': true, '―': true, '—': true, '–': true,
+	'…': true, '‥': true, '＿': true, '＝': true,
+	'（': true, '）': true, '｛': true, '｝': true, '〔': true, '〕': true,
+	'【': true, '】': true, '《': true, '》': true, '〈': true, '〉': true,
+	'「': true, '」': true, '『': true, '』': true, '〖': true, '〗': true,
+}
+
+// verticalShifted lists punctuation moved to the upper-right of the cell
+// in vertical mode when the face has no vertical presentation form for them.
+var verticalShifted = map[rune]bool{
+	'、': true, '。': true, '，': true, '．': true,
+}
+
+// buildVerticalGrid lays out lines as columns of glyphs, top-to-bottom,
+// with the first line on the right. Every glyph occupies a full
+// FontSize×FontSize cell, centered horizontally; glyphs wider than the
+// cell are cropped to it on both sides.
+func buildVerticalGrid(face *mfont.Face, lines []string) [][]bool {
+	cell := face.FontSize()
+
+	rows := 0
+	for _, line := range lines {
+		rows = max(rows, len([]rune(line)))
+	}
+
+	height := rows * cell
+	width := len(lines)*(cell+verticalColumnGap) - verticalColumnGap
+	grid := make([][]bool, height)
+	for y := range grid {
+		grid[y] = make([]bool, width)
+	}
+
+	for i, line := range lines {
+		xOff := (len(lines) - 1 - i) * (cell + verticalColumnGap)
+		for j, r := range []rune(line) {
+			bm := verticalGlyph(face, r)
+			bw := 0
+			if len(bm) > 0 {
+				bw = len(bm[0])
+			}
+			x0 := xOff + (cell-bw)/2
+			y0 := j * cell
+			for y := 0; y < len(bm) && y < cell; y++ {
+				for x := 0; x < len(bm[y]); x++ {
+					if gx := x0 + x; bm[y][x] && gx >= xOff && gx < xOff+cell {
+						grid[y0+y][gx] = true
+					}
+				}
+			}
+		}
+	}
+	return grid
+}
+
+// hideVerticalGlyphs clears the cells of buildVerticalGrid past the first
+// shown[i] glyphs of each line i.
+func hideVerticalGlyphs(grid [][]bool, cell int, lines []string, shown []int) {
+	for i := range lines {
+		xOff := (len(lines) - 1 - i) * (cell + verticalColumnGap)
+		for y := shown[i] * cell; y < len(grid); y++ {
+			clear(grid[y][xOff : xOff+cell])
+		}
+	}
+}
+
+// verticalGlyph returns the bitmap used for r in vertical mode.
+// It prefers the face's vertical presentation form, and otherwise rotates
+// or moves the horizontal glyph to imitate it.
+func verticalGlyph(face *mfont.Face, r rune) [][]bool {
+	if v, ok := verticalForms[r]; ok && face.HasGlyph(v) {
+		return face.GlyphBitmap(v)
+	}
+	bm := face.GlyphBitmap(r)
+	switch {
+	case verticalRotated[r]:
+		return rotateGlyph(bm)
+	case verticalShifted[r]:
+		return shiftGlyphUpRight(bm)
+	}
+	return bm
+}
+
+// rotateGlyph rotates a full-width glyph 90° clockwise.
+// Misaki glyphs are drawn in the top-left (n-1)×(n-1) dots with the last row
+// and column left blank for spacing, so only that box is rotated to keep
+// the spacing in place.
+func rotateGlyph(bm [][]bool) [][]bool {
+	n := len(bm)
+	out := make([][]bool, n)
+	for y := range out {
+		out[y] = make([]bool, n)
+	}
+	box := n - 1
+	for y := 0; y < box; y++ {
+		for x := 0; x < box; x++ {
+			sy, sx := box-1-x, y
+			if sx < len(bm[sy]) && bm[sy][sx] {
+				out[y][x] = true
+			}
+		}
+	}
+	return out
+}
+
+// shiftGlyphUpRight moves the lit dots of a glyph to the top-right corner
+// of the (n-1)×(n-1) drawing box, where vertical punctuation sits. Dots of
+// glyphs wider than n that do not fit are dropped.
+func shiftGlyphUpRight(bm [][]bool) [][]bool {
+	n := len(bm)
+	minX, maxX, minY := n, -1, n
+	for y, row := range bm {
+		for x, on := range row {
+			if on {
+				minX, maxX, minY = min(minX, x), max(maxX, x), min(minY, y)
+			}
+		}
+	}
+	out := make([][]bool, n)
+	for y := range out {
+		out[y] = make([]bool, n)
+	}
+	if maxX < 0 {
+		return out
+	}
+
+	dx := max((n-1)-(maxX+1), -minX)
+	for y, row := range bm {
+		for x, on := range row {
+			if on && x+dx < n {
+				out[y-minY][x+dx] = true
+			}
+		}
+	}
+	return out
+}
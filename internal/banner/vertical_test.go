@@ -0,0 +1,149 @@
+package banner
+
+import (
+	"strings"
+	"testing"
+
+	mfont "github.com/qraqras/misaki-banner/internal/font"
+)
+
+// testBDF is the 6-pixel-high BDF fixture of the font package, with a
+// single 'A'.
+const testBDF = `STARTFONT 2.1
+FONT -test-fixed-medium-r-normal--6-60-75-75-c-40-iso10646-1
+SIZE 6 75 75
+FONTBOUNDINGBOX 4 6 0 -1
+STARTPROPERTIES 2
+FONT_ASCENT 5
+FONT_DESCENT 1
+ENDPROPERTIES
+CHARS 1
+STARTCHAR A
+ENCODING 65
+SWIDTH 666 0
+DWIDTH 4 0
+BBX 3 5 0 0
+BITMAP
+40
+A0
+E0
+A0
+A0
+ENDCHAR
+ENDFONT
+`
+
+// wideBDF is testBDF with 'A' drawn 16 dots wide, wider than its 6-dot cell.
+var wideBDF = strings.NewReplacer(
+	"DWIDTH 4 0", "DWIDTH 16 0",
+	"BBX 3 5 0 0", "BBX 16 5 0 0",
+	"40\nA0\nE0\nA0\nA0", "FFFF\n8001\nFFFF\n8001\nFFFF",
+).Replace(testBDF)
+
+func TestGenerate_Vertical(t *testing.T) {
+	face := newTestFace(t)
+	horizontal := Generate(face, "あい", Options{})
+	vertical := Generate(face, "あい", Options{Vertical: true})
+	if vertical == "" {
+		t.Fatal("Generate with Vertical returned empty")
+	}
+	hLines := strings.Split(horizontal, "\n")
+	vLines := strings.Split(vertical, "\n")
+	if len(vLines) <= len(hLines) {
+		t.Errorf("vertical output has %d lines, want more than horizontal %d", len(vLines), len(hLines))
+	}
+}
+
+func TestGenerate_VerticalMultiLineSingleBlock(t *testing.T) {
+	face := newTestFace(t)
+	result := Generate(face, "あ\nい", Options{Vertical: true})
+	if strings.Contains(result, "\n\n") {
+		t.Error("vertical multi-line output should be a single block of columns")
+	}
+}
+
+func TestBuildVerticalGrid_RightToLeft(t *testing.T) {
+	face := newTestFace(t)
+	grid := buildVerticalGrid(face, []string{"あ", " "})
+	cell := face.FontSize()
+	// The first line is the rightmost column; the second (blank) is on the left
+	for y := 0; y < cell; y++ {
+		for x := 0; x < cell; x++ {
+			if grid[y][x] {
+				t.Fatalf("left column has a lit dot at (%d, %d), want blank", y, x)
+			}
+		}
+	}
+}
+
+func TestRotateGlyph(t *testing.T) {
+	// A horizontal bar in row 3 becomes a vertical bar in column 3
+	bm := make([][]bool, 8)
+	for y := range bm {
+		bm[y] = make([]bool, 8)
+	}
+	for x := 0; x < 7; x++ {
+		bm[3][x] = true
+	}
+	out := rotateGlyph(bm)
+	for y := 0; y < 7; y++ {
+		if !out[y][3] {
+			t.Errorf("rotateGlyph: out[%d][3] = false, want true", y)
+		}
+	}
+}
+
+func TestVerticalGlyph_Punctuation(t *testing.T) {
+	face := newTestFace(t)
+	bm := verticalGlyph(face, '。')
+	// Vertical punctuation sits in the top-right quadrant
+	for y := len(bm) / 2; y < len(bm); y++ {
+		for x := range bm[y] {
+			if bm[y][x] {
+				t.Fatalf("verticalGlyph('。') has a lit dot at (%d, %d) in the lower half", y, x)
+			}
+		}
+	}
+}
+
+func TestGenerate_VerticalWideGlyph(t *testing.T) {
+	face, err := mfont.ParseFace([]byte(wideBDF))
+	if err != nil {
+		t.Fatalf("ParseFace failed: %v", err)
+	}
+	if face.Advance('A') <= face.FontSize() {
+		t.Fatalf("Advance('A') = %d, want wider than the %d-dot cell", face.Advance('A'), face.FontSize())
+	}
+
+	grid := buildVerticalGrid(face, []string{"AA", "A"})
+	if w := len(grid[0]); w != 2*face.FontSize()+verticalColumnGap {
+		t.Errorf("grid is %d dots wide, want two cells and the gap", w)
+	}
+	if Generate(face, "A", Options{Vertical: true}) == "" {
+		t.Error("a glyph wider than the cell should be cropped, not dropped")
+	}
+}
+
+func TestShiftGlyphUpRight_Wide(t *testing.T) {
+	bm := [][]bool{
+		{true, false, false, false, false, false, true},
+		{false, false, false, false, false, false, false},
+		{false, false, false, false, false, false, false},
+	}
+	want := []string{
+		"#..",
+		"...",
+		"...",
+	}
+	got := shiftGlyphUpRight(bm)
+	for y, row := range want {
+		if len(got[y]) != len(row) {
+			t.Fatalf("row %d has %d dots, want %d", y, len(got[y]), len(row))
+		}
+		for x := range row {
+			if got[y][x] != (row[x] == '#') {
+				t.Errorf("dot (%d, %d) = %v, want %v", y, x, got[y][x], row[x] == '#')
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package banner
+
+import (
+	"strings"
+	"unicode"
+
+	mfont "github.com/qraqras/misaki-banner/internal/font"
+)
+
+// noLineStart are runes that must not begin a line (gyoto kinsoku):
+// closing brackets, punctuation, small kana and prolonged sound marks.
+const noLineStart = "、。，．・：；？！゛゜ヽヾゝゞ々ー‐〜～…‥" +
+	"）］｝」』】〕〉》〙〗〟’”" +
+	"ぁぃぅぇぉっゃゅょゎゕゖァィゥェォッャュョヮヵヶ" +
+	",.:;!?)]}"
+
+// noLineEnd are runes that must not end a line (gyomatsu kinsoku):
+// opening brackets.
+const noLineEnd = "（［｛「『【〔〈《〘〖〝‘“" + "([{"
+
+// wrapDots returns the number of glyph dots lines are wrapped to, or 0 if
+// lines are not wrapped. Vertical layout and marquee windows never wrap.
+func (o Options) wrapDots() int {
+	if o.Width <= 0 || o.Vertical || o.Viewport > 0 {
+		return 0
+	}
+	return o.fitDots()
+}
+
+// fitDots returns the number of glyph dots that fit in o.Width terminal
+// columns once the margin, shadow, padding, invert frame and scale are
+// accounted for.
+func (o Options) fitDots() int {
+	// Columns left after the margin on both sides
+	cols := o.Width - 2*max(o.Margin, 0)
+
+	var dots int
+	switch o.Compact {
+	case CompactHalf:
+		dots = cols
+	case CompactQuarter, CompactBraille:
+		dots = 2 * cols
+	default:
+		dots = cols / max(displayWidth(o.charSet().TextOn), 1)
+	}
+
+	if o.Compact == CompactNone && o.charSet().hasShadow() {
+		_, extra, _, _ := shadowSpecOf(o).canvas(0, 0)
+		dots -= extra
+	}
+	if o.Invert {
+		dots -= 2
+	}
+	dots -= 2 * max(o.Padding, 0)
+	return max(dots/max(o.ScaleX, 1), 1)
+}
+
+// wrapLine breaks line into pieces whose glyphs are at most maxDots wide.
+// Latin words are kept whole where possible, and pieces never start with
+// a rune of noLineStart or end with a rune of noLineEnd. A single glyph
+// wider than maxDots gets a piece of its own.
+func wrapLine(face *mfont.Face, line string, maxDots int) []string {
+	runes := []rune(line)
+	widths := make([]int, len(runes))
+	for i, r := range runes {
+		if bm := face.RuneBitmap(r); len(bm) > 0 {
+			widths[i] = len(bm[0])
+		}
+	}
+
+	var pieces []string
+	start, used := 0, 0
+	for i := 0; i < len(runes); i++ {
+		if used+widths[i] <= maxDots || i == start {
+			used += widths[i]
+			continue
+		}
+
+		brk := lineBreak(runes, start, i)
+		pieces = append(pieces, strings.TrimRight(string(runes[start:brk]), " "))
+		for brk < len(runes) && runes[brk] == ' ' {
+			brk++
+		}
+		start, used, i = brk, 0, brk-1
+	}
+	if start < len(runes) {
+		pieces = append(pieces, string(runes[start:]))
+	}
+	return pieces
+}
+
+// lineBreak returns where to break runes[start:end] when runes[end] no
+// longer fits. It backs off to the last space inside a Latin word and then
+// moves runes to the next line until the kinsoku rules hold, as long as
+// something is left on the current line. If that cannot satisfy the rules,
+// the punctuation hangs on the current line instead (burasage), which then
+// overflows.
+func lineBreak(runes []rune, start, end int) int {
+	brk := end
+	if isWordRune(runes[brk]) && isWordRune(runes[brk-1]) {
+		for i := brk - 1; i > start; i-- {
+			if runes[i] == ' ' {
+				brk = i + 1
+				break
+			}
+		}
+	}
+	// violates reports whether breaking at i breaks a kinsoku rule
+	violates := func(i int) bool {
+		return i < len(runes) && (strings.ContainsRune(noLineStart, runes[i]) ||
+			strings.ContainsRune(noLineEnd, runes[i-1]))
+	}
+	for i := brk; i > start; i-- {
+		if !violates(i) {
+			return i
+		}
+	}
+	for violates(brk) {
+		brk++
+	}
+	return brk
+}
+
+// isWordRune reports whether r belongs to a word that should not be split,
+// such as Latin letters and digits.
+func isWordRune(r rune) bool {
+	return r < 0x3000 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,105 @@
+package banner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLineBreak(t *testing.T) {
+	tests := []struct {
+		text       string
+		start, end int
+		want       int
+	}{
+		{"あいうえお", 0, 3, 3},
+		{"あいう。えお", 0, 3, 2},      // 。 never starts a line
+		{"あい「うえ", 0, 3, 2},       // 「 never ends a line
+		{"あい」。うえ", 0, 3, 1},      // consecutive closers move together
+		{"あ」。うえ", 0, 2, 3},       // closers that cannot move hang instead
+		{"。。。。", 0, 1, 4},        // the whole run hangs
+		{"く、ーっ", 0, 3, 4},        // 、 never starts a line even if ー and っ cannot move
+		{"あいう。", 2, 3, 4},        // 。 is not left alone on the last line
+		{"Hello World", 0, 8, 6}, // back off to the word boundary
+		{"HelloWorld", 0, 5, 5},  // a word longer than the line is split
+	}
+	for _, tt := range tests {
+		if got := lineBreak([]rune(tt.text), tt.start, tt.end); got != tt.want {
+			t.Errorf("lineBreak(%q, %d, %d) = %d, want %d", tt.text, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestWrapLine(t *testing.T) {
+	face := newTestFace(t)
+	width := func(s string) int {
+		n := 0
+		for _, r := range s {
+			n += len(face.RuneBitmap(r)[0])
+		}
+		return n
+	}
+
+	pieces := wrapLine(face, "あいう。えお", width("あいう"))
+	want := []string{"あい", "う。え", "お"}
+	if strings.Join(pieces, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapLine = %q, want %q", pieces, want)
+	}
+
+	// Punctuation hangs past the width rather than starting a line
+	pieces = wrapLine(face, "く、ーっ", width("く、ー"))
+	want = []string{"く、ーっ"}
+	if strings.Join(pieces, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapLine = %q, want %q", pieces, want)
+	}
+	pieces = wrapLine(face, "あいう。", width("あ"))
+	want = []string{"あ", "い", "う。"}
+	if strings.Join(pieces, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapLine = %q, want %q", pieces, want)
+	}
+
+	pieces = wrapLine(face, "Hello World", width("Hello Wo"))
+	want = []string{"Hello", "World"}
+	if strings.Join(pieces, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapLine = %q, want %q", pieces, want)
+	}
+}
+
+func TestOptionsWrapDots(t *testing.T) {
+	tests := []struct {
+		opts Options
+		want int
+	}{
+		{Options{}, 0},
+		{Options{Width: 80}, 40},
+		{Options{Width: 80, Compact: CompactHalf}, 80},
+		{Options{Width: 80, Compact: CompactBraille}, 160},
+		{Options{Width: 80, Shadow: ShadowOutline}, 39},
+		{Options{Width: 80, Shadow: ShadowGlow, ShadowDepth: 2}, 36},
+		{Options{Width: 80, ScaleX: 2, Invert: true}, 19},
+		{Options{Width: 80, Vertical: true}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.wrapDots(); got != tt.want {
+			t.Errorf("%+v wrapDots() = %d, want %d", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate_Width(t *testing.T) {
+	face := newTestFace(t)
+	for _, opts := range []Options{
+		{Width: 40},
+		{Width: 40, Shadow: ShadowOutline},
+		{Width: 40, Compact: CompactHalf},
+	} {
+		result := Generate(face, "吾輩は猫である。名前はまだ無い。", opts)
+		for _, line := range strings.Split(result, "\n") {
+			if n := displayWidth(line); n > opts.Width {
+				t.Errorf("%+v line is %d columns wide, want at most %d: %q", opts, n, opts.Width, line)
+			}
+		}
+		if !strings.Contains(result, "\n\n") {
+			t.Errorf("%+v output was not wrapped", opts)
+		}
+	}
+}
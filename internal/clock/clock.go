@@ -0,0 +1,144 @@
+// Package clock formats times and countdowns for the live banners.
+package clock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// era is a Japanese imperial era starting on the given date.
+type era struct {
+	name  string
+	start time.Time
+}
+
+// eras lists the eras from the newest.
+var eras = []era{
+	{"令和", date(2019, time.May, 1)},
+	{"平成", date(1989, time.January, 8)},
+	{"昭和", date(1926, time.December, 25)},
+	{"大正", date(1912, time.July, 30)},
+	{"明治", date(1868, time.January, 25)},
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Era returns the Japanese era of t's calendar date and the year within
+// it, or ok false before Meiji.
+func Era(t time.Time) (name string, year int, ok bool) {
+	d := date(t.Year(), t.Month(), t.Day())
+	for _, e := range eras {
+		if !d.Before(e.start) {
+			return e.name, t.Year() - e.start.Year() + 1, true
+		}
+	}
+	return "", 0, false
+}
+
+var (
+	shortWeekdays = []string{"日", "月", "火", "水", "木", "金", "土"}
+	longWeekdays  = []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"}
+)
+
+// Format formats t with layout. A layout containing "%" is strftime-style,
+// with the Japanese locale's names:
+//
+//	%Y 2025   %y 25   %m 01   %d 02   %e " 2"   %j 002
+//	%H 15   %I 03   %M 04   %S 05   %p 午後
+//	%a 木   %A 木曜日   %EC 令和   %Ey 7   %EY 令和7年 (元年 for the first)   %% %
+//
+// Unknown directives are kept as they are. Any other layout is a Go time
+// layout such as "15:04:05".
+func Format(t time.Time, layout string) string {
+	if !strings.Contains(layout, "%") {
+		return t.Format(layout)
+	}
+
+	var b strings.Builder
+	runes := []rune(layout)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' || i+1 == len(runes) {
+			b.WriteRune(runes[i])
+			continue
+		}
+		i++
+		spec := string(runes[i])
+		if runes[i] == 'E' && i+1 < len(runes) {
+			i++
+			spec += string(runes[i])
+		}
+		b.WriteString(directive(t, spec, "%"+spec))
+	}
+	return b.String()
+}
+
+// directive returns the text of the strftime directive spec for t, or
+// literal if spec is unknown.
+func directive(t time.Time, spec, literal string) string {
+	switch spec {
+	case "Y":
+		return strconv.Itoa(t.Year())
+	case "y":
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "m":
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "d":
+		return fmt.Sprintf("%02d", t.Day())
+	case "e":
+		return fmt.Sprintf("%2d", t.Day())
+	case "j":
+		return fmt.Sprintf("%03d", t.YearDay())
+	case "H":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "I":
+		return fmt.Sprintf("%02d", (t.Hour()+11)%12+1)
+	case "M":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "S":
+		return fmt.Sprintf("%02d", t.Second())
+	case "p":
+		if t.Hour() < 12 {
+			return "午前"
+		}
+		return "午後"
+	case "a":
+		return shortWeekdays[t.Weekday()]
+	case "A":
+		return longWeekdays[t.Weekday()]
+	case "%":
+		return "%"
+	case "EC", "Ey", "EY":
+		name, year, ok := Era(t)
+		if !ok {
+			// Before Meiji the Gregorian year stands in
+			name, year = "", t.Year()
+		}
+		switch spec {
+		case "EC":
+			return name
+		case "Ey":
+			return strconv.Itoa(year)
+		}
+		if year == 1 && ok {
+			return name + "元年"
+		}
+		return name + strconv.Itoa(year) + "年"
+	}
+	return literal
+}
+
+// Countdown formats the time left as MM:SS, or H:MM:SS from an hour up.
+// Partial seconds round up, so the display reaches 00:00 only when the
+// time is up.
+func Countdown(left time.Duration) string {
+	secs := int((max(left, 0) + time.Second - 1) / time.Second)
+	h, m, s := secs/3600, secs/60%60, secs%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
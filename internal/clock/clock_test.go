@@ -0,0 +1,73 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	tm := time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"15:04:05", "15:04:05"}, // Go layout
+		{"%H:%M:%S", "15:04:05"},
+		{"%Y/%m/%d %e %j", "2025/01/02  2 002"},
+		{"%y %I%p", "25 03午後"},
+		{"%m月%d日(%a) %A", "01月02日(木) 木曜日"},
+		{"%EC%Ey年 %EY", "令和7年 令和7年"},
+		{"100%% %Q %", "100% %Q %"},
+	}
+	for _, tt := range tests {
+		if got := Format(tm, tt.layout); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestEra(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		name string
+		year int
+	}{
+		{time.Date(2019, time.April, 30, 23, 0, 0, 0, time.UTC), "平成", 31},
+		{time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC), "令和", 1},
+		{time.Date(1989, time.January, 7, 0, 0, 0, 0, time.UTC), "昭和", 64},
+		{time.Date(1912, time.July, 30, 0, 0, 0, 0, time.UTC), "大正", 1},
+	}
+	for _, tt := range tests {
+		name, year, ok := Era(tt.date)
+		if !ok || name != tt.name || year != tt.year {
+			t.Errorf("Era(%v) = %s %d %v, want %s %d", tt.date, name, year, ok, tt.name, tt.year)
+		}
+	}
+	if _, _, ok := Era(time.Date(1800, time.January, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("Era before Meiji should not be found")
+	}
+
+	first := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+	if got := Format(first, "%EY"); got != "令和元年" {
+		t.Errorf("Format(%%EY) = %q, want 令和元年", got)
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{5 * time.Minute, "05:00"},
+		{5*time.Minute - time.Millisecond, "05:00"},
+		{59 * time.Second, "00:59"},
+		{90*time.Minute + 5*time.Second, "1:30:05"},
+		{0, "00:00"},
+		{-time.Second, "00:00"},
+	}
+	for _, tt := range tests {
+		if got := Countdown(tt.left); got != tt.want {
+			t.Errorf("Countdown(%v) = %q, want %q", tt.left, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 )
 
 // RGB represents a 24-bit color.
@@ -16,6 +17,16 @@ func (c RGB) ANSI() string {
 	return fmt.Sprintf("\033[38;2;%d;%d;%dm", c.R, c.G, c.B)
 }
 
+// BackgroundANSI returns the ANSI 24-bit background escape sequence for this color.
+func (c RGB) BackgroundANSI() string {
+	return fmt.Sprintf("\033[48;2;%d;%d;%dm", c.R, c.G, c.B)
+}
+
+// Hex returns the color in "#rrggbb" notation.
+func (c RGB) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
 // Reset is the ANSI reset escape sequence.
 const Reset = "\033[0m"
 
@@ -96,42 +107,63 @@ var ColorPresets = map[string]RGB{
 	"y": {255, 255, 0},
 }
 
-// ParseColor parses a color string in RGB format "r,g,b", hex format "#RRGGBB"/"RRGGBB", or a preset name.
+// ParseColor parses a color specification. It accepts a preset name
+// (c, m, y), a CSS named color, "#RGB", "#RRGGBB" or "RRGGBB", "r,g,b",
+// the functional notations rgb(), hsl() and oklch(), or an xterm
+// 256-color index such as "208". Errors point at the offending component.
 func ParseColor(s string) (RGB, error) {
-	// Strip leading '#' if present
-	if len(s) > 0 && s[0] == '#' {
-		s = s[1:]
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return RGB{}, fmt.Errorf("empty color")
 	}
 
-	// Check if it's a preset
+	// Check if it's a preset or a named color
 	if c, ok := ColorPresets[s]; ok {
 		return c, nil
 	}
+	if c, ok := cssColors[strings.ToLower(s)]; ok {
+		return c, nil
+	}
 
-	// Try to parse as hex color (e.g., "ffffff")
-	if len(s) == 6 {
-		val, err := strconv.ParseUint(s, 16, 32)
-		if err == nil {
-			return RGB{
-				R: uint8((val & 0xFF0000) >> 16),
-				G: uint8((val & 0x00FF00) >> 8),
-				B: uint8((val & 0x0000FF) >> 0),
-			}, nil
+	if c, ok, err := parseFunc(s); ok {
+		return c, err
+	}
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		// Earlier versions stripped '#' from any color, so keep accepting
+		// "#c", "#tomato" and "#255,0,0"
+		if c, ok := ColorPresets[hex]; ok {
+			return c, nil
+		}
+		if c, ok := cssColors[strings.ToLower(hex)]; ok {
+			return c, nil
 		}
+		if strings.Contains(hex, ",") {
+			return parseTriplet(hex)
+		}
+		return parseHex(hex, 2)
+	}
+	if strings.Contains(s, ",") {
+		return parseTriplet(s)
 	}
 
-	// Try to parse as "r,g,b"
-	var r, g, b int
-	n, err := fmt.Sscanf(s, "%d,%d,%d", &r, &g, &b)
-	if err != nil || n != 3 {
-		return RGB{}, fmt.Errorf("invalid color format: %s (use 'RRGGBB', 'r,g,b' or preset name)", s)
+	// Try to parse as a 256-color index (e.g., "208")
+	if len(s) <= 3 {
+		if i, err := strconv.Atoi(s); err == nil {
+			if i < 0 || i > 255 {
+				return RGB{}, fmt.Errorf("256-color index %d is out of range 0-255", i)
+			}
+			return Xterm256(uint8(i)), nil
+		}
 	}
 
-	if r < 0 || r > 255 || g < 0 || g > 255 || b < 0 || b > 255 {
-		return RGB{}, fmt.Errorf("RGB values must be 0-255")
+	// Try to parse as hex color (e.g., "ffffff")
+	if len(s) == 6 {
+		if c, err := parseHex(s, 1); err == nil {
+			return c, nil
+		}
 	}
 
-	return RGB{uint8(r), uint8(g), uint8(b)}, nil
+	return RGB{}, fmt.Errorf("invalid color format: %s (use a color name, '#RGB', 'RRGGBB', 'r,g,b', rgb(), hsl(), oklch() or a 256-color index)", s)
 }
 
 // ShiftColor shifts a color in HSL space by adjusting hue and lightness.
@@ -61,6 +61,11 @@ func TestParseColor_HexWithHash(t *testing.T) {
 		{"#00ff00", RGB{0, 255, 0}},
 		{"#0000ff", RGB{0, 0, 255}},
 		{"#ffffff", RGB{255, 255, 255}},
+		{"#c", RGB{0, 255, 255}},
+		{"#m", RGB{255, 0, 255}},
+		{"#y", RGB{255, 255, 0}},
+		{"#Red", RGB{255, 0, 0}},
+		{"#255,128,0", RGB{255, 128, 0}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
@@ -150,6 +155,14 @@ func TestRGB_ANSI(t *testing.T) {
 	}
 }
 
+func TestRGB_Hex(t *testing.T) {
+	c := RGB{255, 128, 0}
+	want := "#ff8000"
+	if got := c.Hex(); got != want {
+		t.Errorf("RGB{255,128,0}.Hex() = %q, want %q", got, want)
+	}
+}
+
 func absDiff(a, b uint8) uint8 {
 	d := int(a) - int(b)
 	return uint8(math.Abs(float64(d)))
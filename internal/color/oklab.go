@@ -0,0 +1,106 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// oklab represents a color in the OKLab perceptual color space.
+type oklab struct {
+	L, A, B float64
+}
+
+// srgbToLinear converts an sRGB channel in [0,1] to linear light.
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts a linear light channel to sRGB in [0,1].
+func linearToSRGB(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// rgbToOKLab converts an RGB color to OKLab.
+func rgbToOKLab(c RGB) oklab {
+	r := srgbToLinear(float64(c.R) / 255)
+	g := srgbToLinear(float64(c.G) / 255)
+	b := srgbToLinear(float64(c.B) / 255)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return oklab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// oklabToRGB converts an OKLab color to RGB, clamping out-of-gamut values.
+func oklabToRGB(c oklab) RGB {
+	l := c.L + 0.3963377774*c.A + 0.2158037573*c.B
+	m := c.L - 0.1055613458*c.A - 0.0638541728*c.B
+	s := c.L - 0.0894841775*c.A - 1.2914855480*c.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r := 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g := -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b := -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+
+	channel := func(v float64) uint8 {
+		v = linearToSRGB(v)
+		return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return RGB{channel(r), channel(g), channel(b)}
+}
+
+// Interpolate returns the color at position t in [0,1] along evenly spaced
+// color stops, blending neighbouring stops in OKLab space so the gradient
+// looks perceptually even.
+func Interpolate(stops []RGB, t float64) RGB {
+	switch len(stops) {
+	case 0:
+		return RGB{}
+	case 1:
+		return stops[0]
+	}
+	t = math.Max(0, math.Min(1, t))
+
+	pos := t * float64(len(stops)-1)
+	i := int(pos)
+	if i >= len(stops)-1 {
+		return stops[len(stops)-1]
+	}
+	f := pos - float64(i)
+
+	a, b := rgbToOKLab(stops[i]), rgbToOKLab(stops[i+1])
+	return oklabToRGB(oklab{
+		L: a.L + (b.L-a.L)*f,
+		A: a.A + (b.A-a.A)*f,
+		B: a.B + (b.B-a.B)*f,
+	})
+}
+
+// ParseColorList parses a comma-separated list of colors such as
+// "ff0000,00ff00,0000ff". Each entry accepts the formats of ParseColor
+// except "r,g,b", whose commas would be ambiguous. Commas inside functional
+// notation such as "rgb(255, 0, 0)" do not separate entries.
+func ParseColorList(s string) ([]RGB, error) {
+	var colors []RGB
+	for i, part := range splitColorList(s) {
+		c, err := ParseColor(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("color %d: %w", i+1, err)
+		}
+		colors = append(colors, c)
+	}
+	return colors, nil
+}
@@ -0,0 +1,72 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestOKLab_Roundtrip(t *testing.T) {
+	colors := []RGB{
+		{255, 0, 0},
+		{0, 255, 0},
+		{0, 0, 255},
+		{128, 64, 32},
+		{255, 255, 255},
+		{0, 0, 0},
+	}
+	for _, c := range colors {
+		got := oklabToRGB(rgbToOKLab(c))
+		if absDiff(got.R, c.R) > 1 || absDiff(got.G, c.G) > 1 || absDiff(got.B, c.B) > 1 {
+			t.Errorf("oklabToRGB(rgbToOKLab(%v)) = %v", c, got)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	stops := []RGB{{255, 0, 0}, {0, 255, 0}, {0, 0, 255}}
+	tests := []struct {
+		t    float64
+		want RGB
+	}{
+		{0, RGB{255, 0, 0}},
+		{0.5, RGB{0, 255, 0}},
+		{1, RGB{0, 0, 255}},
+		{-1, RGB{255, 0, 0}},
+		{2, RGB{0, 0, 255}},
+	}
+	for _, tt := range tests {
+		got := Interpolate(stops, tt.t)
+		if absDiff(got.R, tt.want.R) > 1 || absDiff(got.G, tt.want.G) > 1 || absDiff(got.B, tt.want.B) > 1 {
+			t.Errorf("Interpolate(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestInterpolate_Midpoint(t *testing.T) {
+	// Black to white in OKLab passes through OKLab lightness 0.5,
+	// which is sRGB gray 99 rather than the channel average 128
+	got := Interpolate([]RGB{{0, 0, 0}, {255, 255, 255}}, 0.5)
+	want := RGB{99, 99, 99}
+	if absDiff(got.R, want.R) > 1 || got.R != got.G || got.G != got.B {
+		t.Errorf("Interpolate midpoint = %v, want ~%v", got, want)
+	}
+}
+
+func TestParseColorList(t *testing.T) {
+	got, err := ParseColorList("ff0000, #00ff00,c")
+	if err != nil {
+		t.Fatalf("ParseColorList returned error: %v", err)
+	}
+	want := []RGB{{255, 0, 0}, {0, 255, 0}, {0, 255, 255}}
+	if len(got) != len(want) {
+		t.Fatalf("ParseColorList = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParseColorList[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := ParseColorList("ff0000,nope"); err == nil {
+		t.Error("ParseColorList with an invalid entry expected error, got nil")
+	}
+}
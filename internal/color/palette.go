@@ -0,0 +1,84 @@
+package color
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// palettes is the registry of named gradient palettes.
+var palettes = map[string][]RGB{
+	"sunset": {{255, 94, 77}, {255, 154, 0}, {237, 117, 255}},
+	"ocean":  {{0, 180, 219}, {0, 131, 176}, {0, 60, 143}},
+	"fire":   {{255, 240, 0}, {255, 128, 0}, {220, 20, 20}},
+	"forest": {{168, 224, 99}, {86, 171, 47}, {19, 78, 44}},
+	"matrix": {{0, 255, 65}, {0, 143, 17}, {0, 59, 0}},
+	"aurora": {{0, 255, 170}, {0, 170, 255}, {170, 0, 255}},
+	"sakura": {{255, 221, 238}, {255, 153, 204}, {230, 80, 150}},
+	"neon":   {{255, 0, 204}, {51, 51, 255}, {0, 255, 255}},
+	"candy":  {{255, 154, 158}, {250, 208, 196}, {161, 196, 253}},
+	"gold":   {{255, 236, 139}, {255, 196, 0}, {176, 122, 0}},
+	"retro":  {{255, 113, 206}, {1, 205, 254}, {5, 255, 161}, {185, 103, 255}},
+	"mono":   {{255, 255, 255}, {128, 128, 128}, {48, 48, 48}},
+}
+
+// LookupPalette returns the colors of the named palette.
+func LookupPalette(name string) ([]RGB, bool) {
+	colors, ok := palettes[name]
+	return colors, ok
+}
+
+// PaletteNames returns the names of all registered palettes in sorted order.
+func PaletteNames() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterPalette adds or replaces a named palette.
+func RegisterPalette(name string, colors []RGB) error {
+	if name == "" || strings.ContainsAny(name, ", \t") {
+		return fmt.Errorf("invalid palette name: %q", name)
+	}
+	if len(colors) == 0 {
+		return fmt.Errorf("palette %s has no colors", name)
+	}
+	palettes[name] = colors
+	return nil
+}
+
+// LoadPalettes registers the palettes in a JSON config of the form
+// {"name": ["ff0000", "00ff00", ...], ...}. Colors accept the formats of ParseColor.
+func LoadPalettes(r io.Reader) error {
+	var config map[string][]string
+	if err := json.NewDecoder(r).Decode(&config); err != nil {
+		return fmt.Errorf("invalid palette config: %w", err)
+	}
+
+	// Register in a stable order so errors are deterministic
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		colors := make([]RGB, 0, len(config[name]))
+		for i, s := range config[name] {
+			c, err := ParseColor(s)
+			if err != nil {
+				return fmt.Errorf("palette %s: color %d: %w", name, i+1, err)
+			}
+			colors = append(colors, c)
+		}
+		if err := RegisterPalette(name, colors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package color
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaletteNames(t *testing.T) {
+	names := PaletteNames()
+	if len(names) < 12 {
+		t.Errorf("PaletteNames() has %d palettes, want at least 12", len(names))
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Errorf("PaletteNames() is not sorted: %q before %q", names[i-1], names[i])
+		}
+	}
+	for _, name := range names {
+		colors, ok := LookupPalette(name)
+		if !ok || len(colors) < 2 {
+			t.Errorf("palette %q has %d colors, want at least 2", name, len(colors))
+		}
+	}
+}
+
+func TestLookupPalette_Unknown(t *testing.T) {
+	if _, ok := LookupPalette("no_such_palette"); ok {
+		t.Error("LookupPalette(\"no_such_palette\") = ok, want not found")
+	}
+}
+
+func TestLoadPalettes(t *testing.T) {
+	config := `{"test_brand": ["#112233", "c"]}`
+	if err := LoadPalettes(strings.NewReader(config)); err != nil {
+		t.Fatalf("LoadPalettes returned error: %v", err)
+	}
+	t.Cleanup(func() { delete(palettes, "test_brand") })
+
+	colors, ok := LookupPalette("test_brand")
+	if !ok {
+		t.Fatal("LookupPalette(\"test_brand\") not found after LoadPalettes")
+	}
+	want := []RGB{{0x11, 0x22, 0x33}, {0, 255, 255}}
+	for i := range want {
+		if colors[i] != want[i] {
+			t.Errorf("test_brand[%d] = %v, want %v", i, colors[i], want[i])
+		}
+	}
+}
+
+func TestLoadPalettes_Invalid(t *testing.T) {
+	invalids := []string{
+		`not json`,
+		`{"bad": ["nope"]}`,
+		`{"empty": []}`,
+		`{"bad name": ["ff0000"]}`,
+	}
+	for _, config := range invalids {
+		if err := LoadPalettes(strings.NewReader(config)); err == nil {
+			t.Errorf("LoadPalettes(%s) expected error, got nil", config)
+		}
+	}
+}
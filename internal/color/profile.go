@@ -0,0 +1,123 @@
+package color
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Profile is the color depth a terminal supports.
+type Profile int
+
+const (
+	ProfileTrueColor Profile = iota // 24-bit `38;2;r;g;b`
+	Profile256                      // xterm 256-color `38;5;n`
+	Profile16                       // 16 system colors `30-37`, `90-97`
+	ProfileNone                     // no escape sequences
+)
+
+// DetectProfile returns the color depth of a terminal from its environment
+// and whether output goes to a TTY. getenv is typically os.Getenv.
+//
+// NO_COLOR (https://no-color.org) and non-TTY output disable color. Otherwise
+// COLORTERM=truecolor|24bit selects 24-bit color, and TERM selects 256 colors
+// for "*-256color", 24-bit for "*-direct" and 16 colors for anything else.
+// Windows Terminal (WT_SESSION) and Windows consoles without TERM, which
+// set neither variable, get 24-bit color.
+func DetectProfile(getenv func(string) string, tty bool) Profile {
+	return detectProfile(getenv, tty, runtime.GOOS)
+}
+
+func detectProfile(getenv func(string) string, tty bool, goos string) Profile {
+	if getenv("NO_COLOR") != "" || !tty {
+		return ProfileNone
+	}
+	term := strings.ToLower(getenv("TERM"))
+	if term == "dumb" {
+		return ProfileNone
+	}
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+	switch {
+	case strings.HasSuffix(term, "-direct"), strings.Contains(term, "truecolor"):
+		return ProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return Profile256
+	case getenv("WT_SESSION") != "", term == "" && goos == "windows":
+		return ProfileTrueColor
+	}
+	return Profile16
+}
+
+// ANSI returns the foreground escape sequence for c at this color depth,
+// quantizing c to the nearest palette entry when needed.
+func (p Profile) ANSI(c RGB) string {
+	switch p {
+	case Profile256:
+		return fmt.Sprintf("\033[38;5;%dm", Nearest256(c))
+	case Profile16:
+		return fmt.Sprintf("\033[%dm", sgr16(Nearest16(c), 30))
+	case ProfileNone:
+		return ""
+	}
+	return c.ANSI()
+}
+
+// BackgroundANSI returns the background escape sequence for c at this
+// color depth, quantizing c to the nearest palette entry when needed.
+func (p Profile) BackgroundANSI(c RGB) string {
+	switch p {
+	case Profile256:
+		return fmt.Sprintf("\033[48;5;%dm", Nearest256(c))
+	case Profile16:
+		return fmt.Sprintf("\033[%dm", sgr16(Nearest16(c), 40))
+	case ProfileNone:
+		return ""
+	}
+	return c.BackgroundANSI()
+}
+
+// sgr16 returns the SGR parameter of system color i, where base is 30 for
+// foreground or 40 for background. Bright colors use the 90+ range.
+func sgr16(i uint8, base int) int {
+	if i < 8 {
+		return base + int(i)
+	}
+	return base + 60 + int(i) - 8
+}
+
+// xtermLab caches the OKLab value of every xterm 256-color entry.
+var xtermLab = func() (lab [256]oklab) {
+	for i := range lab {
+		lab[i] = rgbToOKLab(Xterm256(uint8(i)))
+	}
+	return lab
+}()
+
+// nearest returns the index in [lo,hi) of the xterm entry perceptually
+// closest to c.
+func nearest(c RGB, lo, hi int) uint8 {
+	want := rgbToOKLab(c)
+	best, bestDist := lo, -1.0
+	for i := lo; i < hi; i++ {
+		e := xtermLab[i]
+		dl, da, db := e.L-want.L, e.A-want.A, e.B-want.B
+		if d := dl*dl + da*da + db*db; bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return uint8(best)
+}
+
+// Nearest256 returns the xterm color cube or grayscale index closest to c.
+// The 16 system colors are skipped since terminal themes redefine them.
+func Nearest256(c RGB) uint8 {
+	return nearest(c, 16, 256)
+}
+
+// Nearest16 returns the system color index (0-15) closest to c.
+func Nearest16(c RGB) uint8 {
+	return nearest(c, 0, 16)
+}
@@ -0,0 +1,82 @@
+package color
+
+import "testing"
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		tty  bool
+		want Profile
+	}{
+		{map[string]string{"COLORTERM": "truecolor", "TERM": "xterm-256color"}, true, ProfileTrueColor},
+		{map[string]string{"COLORTERM": "truecolor"}, false, ProfileNone},
+		{map[string]string{"COLORTERM": "24bit", "NO_COLOR": "1"}, true, ProfileNone},
+		{map[string]string{"TERM": "xterm-256color"}, true, Profile256},
+		{map[string]string{"TERM": "xterm-direct"}, true, ProfileTrueColor},
+		{map[string]string{"TERM": "linux"}, true, Profile16},
+		{map[string]string{"TERM": "dumb"}, true, ProfileNone},
+		{map[string]string{"WT_SESSION": "0b7e9f5c"}, true, ProfileTrueColor},
+		{map[string]string{"WT_SESSION": "0b7e9f5c", "NO_COLOR": "1"}, true, ProfileNone},
+	}
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		if got := DetectProfile(getenv, tt.tty); got != tt.want {
+			t.Errorf("DetectProfile(%v, tty=%v) = %v, want %v", tt.env, tt.tty, got, tt.want)
+		}
+	}
+}
+
+func TestDetectProfile_Windows(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		goos string
+		want Profile
+	}{
+		{map[string]string{}, "windows", ProfileTrueColor},
+		{map[string]string{}, "linux", Profile16},
+		{map[string]string{"TERM": "xterm-256color"}, "windows", Profile256},
+		{map[string]string{"NO_COLOR": "1"}, "windows", ProfileNone},
+	}
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		if got := detectProfile(getenv, true, tt.goos); got != tt.want {
+			t.Errorf("detectProfile(%v, %s) = %v, want %v", tt.env, tt.goos, got, tt.want)
+		}
+	}
+}
+
+func TestNearest256(t *testing.T) {
+	tests := []struct {
+		c    RGB
+		want uint8
+	}{
+		{RGB{255, 0, 0}, 196},
+		{RGB{0, 0, 0}, 16},
+		{RGB{255, 255, 255}, 231},
+		{RGB{128, 128, 128}, 244},
+		{RGB{255, 135, 0}, 208},
+	}
+	for _, tt := range tests {
+		if got := Nearest256(tt.c); got != tt.want {
+			t.Errorf("Nearest256(%v) = %d, want %d", tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestProfileANSI(t *testing.T) {
+	red := RGB{250, 10, 10}
+	tests := []struct {
+		p    Profile
+		want string
+	}{
+		{ProfileTrueColor, "\033[38;2;250;10;10m"},
+		{Profile256, "\033[38;5;196m"},
+		{Profile16, "\033[91m"},
+		{ProfileNone, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.p.ANSI(red); got != tt.want {
+			t.Errorf("Profile(%d).ANSI(%v) = %q, want %q", tt.p, red, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Xterm256 returns the RGB value of an xterm 256-color palette index.
+func Xterm256(i uint8) RGB {
+	switch {
+	case i < 16:
+		return xtermBasic[i]
+	case i < 232:
+		i -= 16
+		return RGB{xtermCube[i/36], xtermCube[i/6%6], xtermCube[i%6]}
+	default:
+		v := 8 + 10*(i-232)
+		return RGB{v, v, v}
+	}
+}
+
+// xtermBasic holds the default xterm values of the 16 system colors.
+var xtermBasic = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// xtermCube holds the channel levels of the 6x6x6 color cube.
+var xtermCube = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// token is a component of a functional color notation and its 1-based
+// column in the color string.
+type token struct {
+	text string
+	col  int
+}
+
+func (t token) errorf(format string, args ...any) error {
+	return fmt.Errorf("%q at column %d %s", t.text, t.col, fmt.Sprintf(format, args...))
+}
+
+// parseFunc parses functional notation such as "rgb(255 0 0)",
+// "hsl(120, 100%, 50%)" or "oklch(0.7 0.15 30 / 0.5)". It reports ok=false
+// when s is not a functional notation at all. Alpha values are accepted and
+// ignored since the output has no transparency.
+func parseFunc(s string) (c RGB, ok bool, err error) {
+	open := strings.IndexByte(s, '(')
+	if open <= 0 {
+		return RGB{}, false, nil
+	}
+	name := strings.ToLower(s[:open])
+	var convert func([]token) (RGB, error)
+	switch name {
+	case "rgb", "rgba":
+		convert = rgbFunc
+	case "hsl", "hsla":
+		convert = hslFunc
+	case "oklch":
+		convert = oklchFunc
+	default:
+		return RGB{}, true, fmt.Errorf("unknown color function %q (use rgb, hsl or oklch)", name)
+	}
+	if !strings.HasSuffix(s, ")") {
+		return RGB{}, true, fmt.Errorf("missing \")\" at column %d", len(s)+1)
+	}
+
+	args, alpha := splitArgs(s[open+1:len(s)-1], open+2)
+	if len(args) == 4 && alpha == nil {
+		// Legacy comma syntax passes alpha as a fourth component
+		alpha, args = &args[3], args[:3]
+	}
+	if len(args) != 3 {
+		return RGB{}, true, fmt.Errorf("%s() takes 3 components, got %d", name, len(args))
+	}
+	if alpha != nil {
+		if _, err := percentOrNumber(*alpha, 0, 1, 1); err != nil {
+			return RGB{}, true, err
+		}
+	}
+	c, err = convert(args)
+	return c, true, err
+}
+
+// splitArgs splits the arguments of a color function on commas and
+// whitespace. A component after "/" is returned separately as alpha.
+// offset is the column of the first character of args.
+func splitArgs(args string, offset int) ([]token, *token) {
+	var tokens []token
+	var alpha *token
+	slash := false
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		t := token{args[start:end], offset + start}
+		if slash {
+			alpha = &t
+		} else {
+			tokens = append(tokens, t)
+		}
+		start = -1
+	}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case ' ', '\t', ',':
+			flush(i)
+		case '/':
+			flush(i)
+			slash = true
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	flush(len(args))
+	return tokens, alpha
+}
+
+// number parses a plain number component.
+func number(t token) (float64, error) {
+	v, err := strconv.ParseFloat(t.text, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, t.errorf("is not a number")
+	}
+	return v, nil
+}
+
+// percentOrNumber parses a number in [lo,hi] or a percentage, which maps
+// 100% to scale.
+func percentOrNumber(t token, lo, hi, scale float64) (float64, error) {
+	if p, ok := strings.CutSuffix(t.text, "%"); ok {
+		v, err := number(token{p, t.col})
+		if err != nil {
+			return 0, t.errorf("is not a percentage")
+		}
+		if v < 0 || v > 100 {
+			return 0, t.errorf("is out of range 0%%-100%%")
+		}
+		return v / 100 * scale, nil
+	}
+	v, err := number(t)
+	if err != nil {
+		return 0, err
+	}
+	if v < lo || v > hi {
+		return 0, t.errorf("is out of range %g-%g", lo, hi)
+	}
+	return v, nil
+}
+
+// hue parses an angle in degrees, with an optional "deg" unit, and
+// normalizes it to [0,360).
+func hue(t token) (float64, error) {
+	v, err := number(token{strings.TrimSuffix(strings.ToLower(t.text), "deg"), t.col})
+	if err != nil {
+		return 0, t.errorf("is not an angle")
+	}
+	return math.Mod(math.Mod(v, 360)+360, 360), nil
+}
+
+func rgbFunc(args []token) (RGB, error) {
+	var ch [3]uint8
+	for i, t := range args {
+		v, err := percentOrNumber(t, 0, 255, 255)
+		if err != nil {
+			return RGB{}, err
+		}
+		ch[i] = uint8(math.Round(v))
+	}
+	return RGB{ch[0], ch[1], ch[2]}, nil
+}
+
+func hslFunc(args []token) (RGB, error) {
+	h, err := hue(args[0])
+	if err != nil {
+		return RGB{}, err
+	}
+	s, err := percentOrNumber(args[1], 0, 100, 100)
+	if err != nil {
+		return RGB{}, err
+	}
+	l, err := percentOrNumber(args[2], 0, 100, 100)
+	if err != nil {
+		return RGB{}, err
+	}
+	return hslToRGB(hsl{h, s / 100, l / 100}), nil
+}
+
+func oklchFunc(args []token) (RGB, error) {
+	l, err := percentOrNumber(args[0], 0, 1, 1)
+	if err != nil {
+		return RGB{}, err
+	}
+	c, err := percentOrNumber(args[1], 0, math.MaxFloat64, 0.4)
+	if err != nil {
+		return RGB{}, err
+	}
+	h, err := hue(args[2])
+	if err != nil {
+		return RGB{}, err
+	}
+	rad := h * math.Pi / 180
+	return oklabToRGB(oklab{L: l, A: c * math.Cos(rad), B: c * math.Sin(rad)}), nil
+}
+
+// parseHex parses the digits of "#RGB" or "#RRGGBB" notation. col is the
+// column of the first digit.
+func parseHex(s string, col int) (RGB, error) {
+	if len(s) != 3 && len(s) != 6 {
+		return RGB{}, fmt.Errorf("hex color must have 3 or 6 digits, got %d", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		if !isHexDigit(s[i]) {
+			return RGB{}, token{s[i : i+1], col + i}.errorf("is not a hex digit")
+		}
+	}
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	val, _ := strconv.ParseUint(s, 16, 32)
+	return RGB{uint8(val >> 16), uint8(val >> 8), uint8(val)}, nil
+}
+
+func isHexDigit(b byte) bool {
+	return '0' <= b && b <= '9' || 'a' <= b && b <= 'f' || 'A' <= b && b <= 'F'
+}
+
+// parseTriplet parses "r,g,b".
+func parseTriplet(s string) (RGB, error) {
+	args, _ := splitArgs(s, 1)
+	if len(args) != 3 {
+		return RGB{}, fmt.Errorf("r,g,b color needs 3 components, got %d", len(args))
+	}
+	var ch [3]uint8
+	for i, t := range args {
+		v, err := strconv.Atoi(t.text)
+		if err != nil {
+			return RGB{}, t.errorf("is not an integer")
+		}
+		if v < 0 || v > 255 {
+			return RGB{}, t.errorf("is out of range 0-255")
+		}
+		ch[i] = uint8(v)
+	}
+	return RGB{ch[0], ch[1], ch[2]}, nil
+}
+
+// splitColorList splits s on commas that are not inside parentheses.
+func splitColorList(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth = max(depth-1, 0)
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
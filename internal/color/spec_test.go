@@ -0,0 +1,113 @@
+package color
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseColor_Spec(t *testing.T) {
+	tests := []struct {
+		input string
+		want  RGB
+	}{
+		{"red", RGB{255, 0, 0}},
+		{"RebeccaPurple", RGB{102, 51, 153}},
+		{"#f80", RGB{255, 136, 0}},
+		{"#F80", RGB{255, 136, 0}},
+		{"rgb(255, 128, 0)", RGB{255, 128, 0}},
+		{"rgb(255 128 0 / 0.5)", RGB{255, 128, 0}},
+		{"rgba(100%, 0%, 50%, 1)", RGB{255, 0, 128}},
+		{"hsl(120, 100%, 50%)", RGB{0, 255, 0}},
+		{"hsl(-120deg 100% 50%)", RGB{0, 0, 255}},
+		{"oklch(1 0 0)", RGB{255, 255, 255}},
+		{"oklch(0% 0 0)", RGB{0, 0, 0}},
+		{"0", RGB{0, 0, 0}},
+		{"9", RGB{255, 0, 0}},
+		{"208", RGB{255, 135, 0}},
+		{"255", RGB{238, 238, 238}},
+		{" 255,0,0 ", RGB{255, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseColor(tt.input)
+			if err != nil {
+				t.Fatalf("ParseColor(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseColor(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseColor_OKLCH(t *testing.T) {
+	// oklch(0.628 0.2577 29.23) is the OKLCH form of pure red
+	got, err := ParseColor("oklch(0.628 0.2577 29.23)")
+	if err != nil {
+		t.Fatalf("ParseColor returned error: %v", err)
+	}
+	if absDiff(got.R, 255) > 2 || got.G > 2 || got.B > 2 {
+		t.Errorf("ParseColor(oklch red) = %v, want ~{255 0 0}", got)
+	}
+}
+
+func TestParseColor_ErrorPosition(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"rgb(255, 300, 0)", `"300" at column 10`},
+		{"hsl(abc 50% 50%)", `"abc" at column 5`},
+		{"#12g", `"g" at column 4`},
+		{"10,x,0", `"x" at column 4`},
+		{"rgb(1 2)", "takes 3 components, got 2"},
+		{"rgb(1 2 3", `missing ")"`},
+		{"cmyk(0 0 0 0)", `unknown color function "cmyk"`},
+		{"300", "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseColor(tt.input)
+			if err == nil {
+				t.Fatalf("ParseColor(%q) expected error, got nil", tt.input)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseColor(%q) error = %q, want it to mention %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestXterm256(t *testing.T) {
+	tests := []struct {
+		index uint8
+		want  RGB
+	}{
+		{1, RGB{205, 0, 0}},
+		{16, RGB{0, 0, 0}},
+		{196, RGB{255, 0, 0}},
+		{231, RGB{255, 255, 255}},
+		{232, RGB{8, 8, 8}},
+	}
+	for _, tt := range tests {
+		if got := Xterm256(tt.index); got != tt.want {
+			t.Errorf("Xterm256(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorList_Functional(t *testing.T) {
+	colors, err := ParseColorList("rgb(255, 0, 0),hsl(240, 100%, 50%), navy")
+	if err != nil {
+		t.Fatalf("ParseColorList returned error: %v", err)
+	}
+	want := []RGB{{255, 0, 0}, {0, 0, 255}, {0, 0, 128}}
+	if len(colors) != len(want) {
+		t.Fatalf("ParseColorList = %v, want %v", colors, want)
+	}
+	for i := range want {
+		if colors[i] != want[i] {
+			t.Errorf("colors[%d] = %v, want %v", i, colors[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package font
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseBDF parses a font in the Glyph Bitmap Distribution Format.
+func parseBDF(data []byte) (*Face, error) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	// Glyphs are keyed by rune once the charset is known
+	type bdfChar struct {
+		code  int
+		glyph *bitmapGlyph
+	}
+
+	var (
+		ascent, descent    int
+		hasAscent          bool
+		hasDescent         bool
+		bbH, bbYOff        int
+		registry, encoding string
+		chars              []bdfChar
+		cur                *bitmapGlyph
+		code               int
+		bbxW               int
+		inBitmap           bool
+		lineNo             int
+	)
+	errorf := func(format string, args ...any) error {
+		return fmt.Errorf("invalid BDF (line %d): %s", lineNo, fmt.Sprintf(format, args...))
+	}
+
+	// ints parses n integer fields following the keyword.
+	ints := func(fields []string, n int) ([]int, error) {
+		if len(fields) < n+1 {
+			return nil, errorf("%s needs %d values", fields[0], n)
+		}
+		vals := make([]int, n)
+		for i := range vals {
+			v, err := strconv.Atoi(fields[i+1])
+			if err != nil {
+				return nil, errorf("%s: %v", fields[0], err)
+			}
+			vals[i] = v
+		}
+		return vals, nil
+	}
+
+	for sc.Scan() {
+		lineNo++
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if inBitmap {
+			if fields[0] == "ENDCHAR" {
+				inBitmap = false
+				chars = append(chars, bdfChar{code: code, glyph: cur})
+				cur = nil
+				continue
+			}
+			row, err := parseHexRow(fields[0], bbxW)
+			if err != nil {
+				return nil, errorf("%v", err)
+			}
+			cur.bits = append(cur.bits, row)
+			continue
+		}
+
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			v, err := ints(fields, 4)
+			if err != nil {
+				return nil, err
+			}
+			bbH, bbYOff = v[1], v[3]
+		case "FONT_ASCENT":
+			v, err := ints(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			ascent, hasAscent = v[0], true
+		case "FONT_DESCENT":
+			v, err := ints(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			descent, hasDescent = v[0], true
+		case "CHARSET_REGISTRY":
+			registry = strings.Trim(strings.Join(fields[1:], " "), `"`)
+		case "CHARSET_ENCODING":
+			encoding = strings.Trim(strings.Join(fields[1:], " "), `"`)
+		case "STARTCHAR":
+			cur = &bitmapGlyph{}
+			code = -1
+			bbxW = 0
+		case "ENCODING":
+			v, err := ints(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			code = v[0]
+		case "DWIDTH":
+			if cur == nil {
+				return nil, errorf("DWIDTH outside of a glyph")
+			}
+			v, err := ints(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			// A negative advance would make an invalid bitmap width
+			cur.advance = max(v[0], 0)
+		case "BBX":
+			if cur == nil {
+				return nil, errorf("BBX outside of a glyph")
+			}
+			v, err := ints(fields, 4)
+			if err != nil {
+				return nil, err
+			}
+			if v[0] < 0 {
+				return nil, errorf("BBX width must not be negative")
+			}
+			bbxW = v[0]
+			cur.left = v[2]
+			cur.top = v[1] + v[3]
+		case "BITMAP":
+			if cur == nil {
+				return nil, errorf("BITMAP outside of a glyph")
+			}
+			inBitmap = true
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read BDF: %w", err)
+	}
+
+	// Properties are optional; derive the cell from the bounding box
+	if !hasAscent {
+		ascent = bbH + bbYOff
+	}
+	if !hasDescent {
+		descent = -bbYOff
+	}
+	if ascent+descent <= 0 {
+		return nil, fmt.Errorf("invalid BDF: cannot determine the glyph cell height")
+	}
+
+	glyphs := make(map[rune]*bitmapGlyph, len(chars))
+	for _, c := range chars {
+		if r, ok := charsetRune(registry, encoding, c.code); ok {
+			glyphs[r] = c.glyph
+		}
+	}
+	if len(glyphs) == 0 {
+		return nil, fmt.Errorf("invalid BDF: no glyphs")
+	}
+	return newBitmapFace(glyphs, ascent, descent), nil
+}
+
+// parseHexRow decodes one BITMAP row of hex digits into width pixels.
+func parseHexRow(s string, width int) ([]bool, error) {
+	row := make([]bool, width)
+	for i := 0; i < len(s); i++ {
+		v, err := strconv.ParseUint(s[i:i+1], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("bad bitmap row %q", s)
+		}
+		for b := 0; b < 4; b++ {
+			x := i*4 + b
+			if x < width && v&(8>>b) != 0 {
+				row[x] = true
+			}
+		}
+	}
+	return row, nil
+}
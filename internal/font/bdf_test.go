@@ -0,0 +1,114 @@
+package font
+
+import (
+	"strings"
+	"testing"
+)
+
+// testBDF is a 6-pixel-high font with an 'A' and a JIS-encoded 'あ'.
+const testBDF = `STARTFONT 2.1
+FONT -test-fixed-medium-r-normal--6-60-75-75-c-40-iso10646-1
+SIZE 6 75 75
+FONTBOUNDINGBOX 4 6 0 -1
+STARTPROPERTIES 2
+FONT_ASCENT 5
+FONT_DESCENT 1
+ENDPROPERTIES
+CHARS 1
+STARTCHAR A
+ENCODING 65
+SWIDTH 666 0
+DWIDTH 4 0
+BBX 3 5 0 0
+BITMAP
+40
+A0
+E0
+A0
+A0
+ENDCHAR
+ENDFONT
+`
+
+func TestParseBDF(t *testing.T) {
+	face, err := ParseFace([]byte(testBDF))
+	if err != nil {
+		t.Fatalf("ParseFace failed: %v", err)
+	}
+	if face.FontSize() != 6 {
+		t.Errorf("FontSize() = %d, want 6", face.FontSize())
+	}
+	if face.Advance('A') != 4 {
+		t.Errorf("Advance('A') = %d, want 4", face.Advance('A'))
+	}
+
+	want := []string{
+		".#..",
+		"#.#.",
+		"###.",
+		"#.#.",
+		"#.#.",
+		"....",
+	}
+	bm := face.GlyphBitmap('A')
+	for y, row := range want {
+		for x, c := range row {
+			if bm[y][x] != (c == '#') {
+				t.Fatalf("GlyphBitmap('A')[%d][%d] = %v, want %v", y, x, bm[y][x], c == '#')
+			}
+		}
+	}
+}
+
+func TestParseBDF_JISX0208(t *testing.T) {
+	data := `STARTFONT 2.1
+FONTBOUNDINGBOX 8 8 0 -1
+STARTPROPERTIES 2
+CHARSET_REGISTRY "JISX0208.1983"
+CHARSET_ENCODING "0"
+ENDPROPERTIES
+STARTCHAR 2422
+ENCODING 9250
+DWIDTH 8 0
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+ENDFONT
+`
+	face, err := ParseFace([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseFace failed: %v", err)
+	}
+	if !face.HasGlyph('あ') {
+		t.Error("HasGlyph('あ') = false, want true (JIS 0x2422)")
+	}
+	if face.FontSize() != 8 {
+		t.Errorf("FontSize() = %d, want 8 from FONTBOUNDINGBOX", face.FontSize())
+	}
+}
+
+func TestParseBDF_NegativeAdvance(t *testing.T) {
+	face, err := ParseFace([]byte(strings.Replace(testBDF, "DWIDTH 4 0", "DWIDTH -4 0", 1)))
+	if err != nil {
+		t.Fatalf("ParseFace failed: %v", err)
+	}
+	if face.Advance('A') != 0 {
+		t.Errorf("Advance('A') = %d, want a negative DWIDTH clamped to 0", face.Advance('A'))
+	}
+	face.RuneBitmap('A')
+}
+
+func TestParseBDF_NegativeBBX(t *testing.T) {
+	data := strings.Replace(testBDF, "BBX 3 5 0 0", "BBX -3 8 0 -1", 1)
+	if _, err := ParseFace([]byte(data)); err == nil {
+		t.Error("ParseFace with a negative BBX width expected error, got nil")
+	}
+}
+
+func TestParseBDF_Invalid(t *testing.T) {
+	data := "STARTFONT 2.1\nFONTBOUNDINGBOX 8 x 0 0\nENDFONT\n"
+	if _, err := ParseFace([]byte(data)); err == nil {
+		t.Error("ParseFace with a bad FONTBOUNDINGBOX expected error, got nil")
+	}
+}
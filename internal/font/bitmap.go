@@ -0,0 +1,105 @@
+package font
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// bitmapGlyph is a single glyph of a BDF or PCF bitmap font.
+type bitmapGlyph struct {
+	advance int      // horizontal advance in pixels
+	left    int      // offset of the first bitmap column from the origin
+	top     int      // height of the first bitmap row above the baseline
+	bits    [][]bool // glyph bitmap, true means the pixel is "on"
+}
+
+// bitmapSource draws glyphs of a BDF or PCF bitmap font.
+type bitmapSource struct {
+	glyphs map[rune]*bitmapGlyph
+	ascent int
+}
+
+func (s *bitmapSource) hasGlyph(r rune) bool {
+	_, ok := s.glyphs[r]
+	return ok
+}
+
+func (s *bitmapSource) advance(r rune) (int, bool) {
+	g, ok := s.glyphs[r]
+	if !ok {
+		return 0, false
+	}
+	return g.advance, true
+}
+
+func (s *bitmapSource) draw(r rune, size, adv int) [][]bool {
+	raw := make([][]bool, size)
+	for y := range raw {
+		raw[y] = make([]bool, adv)
+	}
+	g, ok := s.glyphs[r]
+	if !ok {
+		return raw
+	}
+	// Pixels outside the cell are clipped
+	for i, row := range g.bits {
+		y := s.ascent - g.top + i
+		if y < 0 || y >= size {
+			continue
+		}
+		for j, on := range row {
+			x := g.left + j
+			if on && x >= 0 && x < adv {
+				raw[y][x] = true
+			}
+		}
+	}
+	return raw
+}
+
+// newBitmapFace creates a face from parsed bitmap glyphs.
+func newBitmapFace(glyphs map[rune]*bitmapGlyph, ascent, descent int) *Face {
+	return &Face{
+		src:      &bitmapSource{glyphs: glyphs, ascent: ascent},
+		fontSize: ascent + descent,
+	}
+}
+
+// charsetRune converts a glyph code in the font's charset to a rune.
+// It returns false for charsets or codes that cannot be mapped.
+func charsetRune(registry, encoding string, code int) (rune, bool) {
+	if code < 0 {
+		return 0, false
+	}
+	registry = strings.ToUpper(registry)
+	switch {
+	case registry == "" || registry == "ISO10646" || registry == "UNICODE":
+		return rune(code), true
+	case registry == "ISO8859" && encoding == "1":
+		return rune(code), code < 0x100
+	case strings.HasPrefix(registry, "JISX0208"):
+		// Decode the 2-byte JIS code as EUC-JP
+		b := []byte{byte(code>>8) | 0x80, byte(code) | 0x80}
+		s, err := japanese.EUCJP.NewDecoder().Bytes(b)
+		if err != nil {
+			return 0, false
+		}
+		rs := []rune(string(s))
+		if len(rs) != 1 || rs[0] == '�' {
+			return 0, false
+		}
+		return rs[0], true
+	case strings.HasPrefix(registry, "JISX0201"):
+		switch {
+		case code < 0x80:
+			return rune(code), true
+		case code >= 0xA1 && code <= 0xDF:
+			// Half-width katakana
+			return rune(0xFF61 + code - 0xA1), true
+		}
+		return 0, false
+	}
+	// Fall back to ASCII, which most charsets share
+	return rune(code), code < 0x80
+}
@@ -0,0 +1,119 @@
+package font
+
+import (
+	"unicode"
+)
+
+// WithFallback returns a copy of f that renders runes missing from f with
+// the first of faces that has a glyph for them. Fallback glyphs are fitted
+// to the cell height of f.
+func (f *Face) WithFallback(faces ...*Face) *Face {
+	nf := *f
+	nf.fallbacks = append(append([]*Face(nil), f.fallbacks...), faces...)
+	return &nf
+}
+
+// WithReplacement returns a copy of f that draws a replacement box for
+// runes that no face in the fallback chain can render.
+func (f *Face) WithReplacement() *Face {
+	nf := *f
+	nf.replacement = true
+	return &nf
+}
+
+// MissingRunes returns the runes of text that no face in the fallback chain
+// can render, in order of first appearance. Control characters are ignored.
+func (f *Face) MissingRunes(text string) []rune {
+	var missing []rune
+	seen := make(map[rune]bool)
+	for _, r := range text {
+		if seen[r] || unicode.IsControl(r) {
+			continue
+		}
+		seen[r] = true
+		if _, ok := f.resolve(r); !ok {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// resolve returns the face in the fallback chain that has a glyph for r.
+// It returns f and false if no face has one.
+func (f *Face) resolve(r rune) (*Face, bool) {
+	if f.src.hasGlyph(r) {
+		return f, true
+	}
+	for _, fb := range f.fallbacks {
+		if fb.HasGlyph(r) {
+			return fb, true
+		}
+	}
+	return f, false
+}
+
+// fitScale returns the factor num/den by which glyphs of fb are scaled to
+// fit the cell of f. Only taller faces are scaled down; shorter ones are
+// padded instead.
+func (f *Face) fitScale(fb *Face) (int, int) {
+	if fb.fontSize > f.fontSize {
+		return f.fontSize, fb.fontSize
+	}
+	return 1, 1
+}
+
+// fitCell fits a glyph bitmap of a fallback face into a cell size rows high
+// and adv columns wide. Taller glyphs are scaled down (nearest neighbour),
+// shorter ones are centered vertically.
+func fitCell(bm [][]bool, size, adv int) [][]bool {
+	out := make([][]bool, size)
+	for y := range out {
+		out[y] = make([]bool, adv)
+	}
+	h := len(bm)
+	if h == 0 {
+		return out
+	}
+	w := len(bm[0])
+
+	if h <= size {
+		yOff := (size - h) / 2
+		for y, row := range bm {
+			for x := 0; x < adv && x < w; x++ {
+				out[y+yOff][x] = row[x]
+			}
+		}
+		return out
+	}
+	for y := 0; y < size; y++ {
+		sy := y * h / size
+		for x := 0; x < adv; x++ {
+			sx := x * h / size
+			if sx < w {
+				out[y][x] = bm[sy][sx]
+			}
+		}
+	}
+	return out
+}
+
+// replacementGlyph returns a hollow box filling the drawing area of a
+// size×adv cell, leaving the last row and column blank for spacing.
+func replacementGlyph(size, adv int) [][]bool {
+	bm := make([][]bool, size)
+	for y := range bm {
+		bm[y] = make([]bool, adv)
+	}
+	bottom, right := size-2, adv-2
+	if bottom < 0 || right < 0 {
+		return bm
+	}
+	for y := 0; y <= bottom; y++ {
+		for x := 0; x <= right; x++ {
+			if y == 0 || y == bottom || x == 0 || x == right {
+				bm[y][x] = true
+			}
+		}
+	}
+	return bm
+}
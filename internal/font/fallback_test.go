@@ -0,0 +1,90 @@
+package font
+
+import (
+	"testing"
+)
+
+func TestWithFallback(t *testing.T) {
+	face, err := NewFace(FontMisakiGothic2nd)
+	if err != nil {
+		t.Fatalf("NewFace failed: %v", err)
+	}
+	bdf, err := ParseFace([]byte(testBDF))
+	if err != nil {
+		t.Fatalf("ParseFace failed: %v", err)
+	}
+
+	// testBDF only has 'A', so use it as the primary and Misaki as fallback
+	chained := bdf.WithFallback(face)
+	if !chained.HasGlyph('あ') {
+		t.Error("HasGlyph('あ') = false with Misaki fallback, want true")
+	}
+	if bdf.HasGlyph('あ') {
+		t.Error("WithFallback modified the original face")
+	}
+
+	bm := chained.GlyphBitmap('あ')
+	if len(bm) != chained.FontSize() {
+		t.Fatalf("fallback glyph height = %d, want %d", len(bm), chained.FontSize())
+	}
+	hasPixel := false
+	for _, row := range bm {
+		for _, v := range row {
+			hasPixel = hasPixel || v
+		}
+	}
+	if !hasPixel {
+		t.Error("fallback glyph for 'あ' is blank")
+	}
+}
+
+func TestMissingRunes(t *testing.T) {
+	face, err := NewFace(FontMisakiGothic2nd)
+	if err != nil {
+		t.Fatalf("NewFace failed: %v", err)
+	}
+	got := face.MissingRunes("あ😀い😀\n한")
+	want := []rune{'😀', '한'}
+	if string(got) != string(want) {
+		t.Errorf("MissingRunes = %q, want %q", string(got), string(want))
+	}
+}
+
+func TestWithReplacement(t *testing.T) {
+	face, err := NewFace(FontMisakiGothic2nd)
+	if err != nil {
+		t.Fatalf("NewFace failed: %v", err)
+	}
+	face = face.WithReplacement()
+	bm := face.GlyphBitmap('😀')
+	if len(bm) != misakiFontSize || len(bm[0]) != misakiFontSize {
+		t.Fatalf("replacement glyph size = %dx%d, want %dx%d", len(bm[0]), len(bm), misakiFontSize, misakiFontSize)
+	}
+	if !bm[0][0] || !bm[misakiFontSize-2][misakiFontSize-2] || bm[3][3] {
+		t.Error("replacement glyph is not a hollow box")
+	}
+	// The replacement does not count as rendered
+	if len(face.MissingRunes("😀")) != 1 {
+		t.Error("MissingRunes should still report runes drawn as replacement boxes")
+	}
+}
+
+func TestFitCell(t *testing.T) {
+	// A 4-row glyph scaled into 2 rows
+	bm := [][]bool{
+		{true, true, true, true},
+		{true, true, true, true},
+		{false, false, false, false},
+		{false, false, false, false},
+	}
+	out := fitCell(bm, 2, 2)
+	if !out[0][0] || !out[0][1] || out[1][0] || out[1][1] {
+		t.Errorf("fitCell scale down = %v", out)
+	}
+
+	// A 1-row glyph centered in 3 rows
+	out = fitCell([][]bool{{true}}, 3, 1)
+	if out[0][0] || !out[1][0] || out[2][0] {
+		t.Errorf("fitCell center = %v", out)
+	}
+}
@@ -0,0 +1,114 @@
+package font
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// defaultOutlineSize is the pixel size used for outline fonts that are not
+// drawn on a pixel grid.
+const defaultOutlineSize = 16
+
+// pixelProbeRunes are sampled to find the pixel grid of an outline font.
+const pixelProbeRunes = "AHMWagmw0あ漢"
+
+// LoadFace creates a font face from a font file.
+// TrueType/OpenType, BDF and PCF (optionally gzip-compressed) files are supported.
+func LoadFace(path string) (*Face, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font: %w", err)
+	}
+	return ParseFace(data)
+}
+
+// ParseFace creates a font face from font file data, detecting the format
+// and the glyph cell height.
+func ParseFace(data []byte) (*Face, error) {
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress font: %w", err)
+		}
+		data, err = io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress font: %w", err)
+		}
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte(pcfMagic)):
+		return parsePCF(data)
+	case bytes.HasPrefix(data, []byte("STARTFONT")):
+		return parseBDF(data)
+	}
+
+	ft, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font (expected TTF, OTF, BDF or PCF): %w", err)
+	}
+	return newOutlineFace(ft, detectPixelSize(ft))
+}
+
+// detectPixelSize guesses the pixel size of an outline font. Pixel fonts
+// converted to outlines place every point on a grid, so the greatest common
+// divisor of the point coordinates is one pixel in font units.
+func detectPixelSize(ft *sfnt.Font) int {
+	var buf sfnt.Buffer
+	upem := int(ft.UnitsPerEm())
+	unit := 0
+	for _, r := range pixelProbeRunes {
+		idx, err := ft.GlyphIndex(&buf, r)
+		if err != nil || idx == 0 {
+			continue
+		}
+		// At a ppem of unitsPerEm, one pixel is one font unit
+		segs, err := ft.LoadGlyph(&buf, idx, fixed.I(upem), nil)
+		if err != nil {
+			continue
+		}
+		for _, seg := range segs {
+			n := 1
+			switch seg.Op {
+			case sfnt.SegmentOpQuadTo:
+				n = 2
+			case sfnt.SegmentOpCubeTo:
+				n = 3
+			}
+			for _, p := range seg.Args[:n] {
+				unit = gcd(unit, p.X.Round())
+				unit = gcd(unit, p.Y.Round())
+			}
+		}
+	}
+	if unit == 0 {
+		return defaultOutlineSize
+	}
+
+	size := (upem + unit/2) / unit
+	if size < 4 || size > 64 {
+		return defaultOutlineSize
+	}
+	return size
+}
+
+// gcd returns the greatest common divisor of |a| and |b|.
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
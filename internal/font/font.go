@@ -8,6 +8,7 @@ import (
 	"github.com/qraqras/misaki-banner/misaki"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
 	"golang.org/x/image/math/fixed"
 )
 
@@ -32,10 +33,24 @@ var fonts = map[FontName]fontDef{
 	FontMisakiMincho:    {data: misaki.MinchoTTF},
 }
 
+// glyphSource provides the glyphs of a Face.
+type glyphSource interface {
+	// hasGlyph reports whether the font defines a glyph for r.
+	hasGlyph(r rune) bool
+	// advance returns the horizontal advance of r in pixels, and false if
+	// the font does not define it.
+	advance(r rune) (int, bool)
+	// draw returns the bitmap of r, size rows high and adv columns wide,
+	// with the baseline at the font's ascent.
+	draw(r rune, size, adv int) [][]bool
+}
+
 // Face holds a parsed font face ready for rendering.
 type Face struct {
-	face     font.Face
-	fontSize int
+	src         glyphSource
+	fontSize    int
+	fallbacks   []*Face // consulted in order for runes missing from src
+	replacement bool    // draw a box for runes no face can render
 }
 
 // FontSize returns the pixel height of this font face.
@@ -54,9 +69,13 @@ func NewFace(name FontName) (*Face, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse font: %w", err)
 	}
+	return newOutlineFace(ft, misakiFontSize)
+}
 
+// newOutlineFace creates a face that rasterizes an outline font at the given pixel size.
+func newOutlineFace(ft *sfnt.Font, size int) (*Face, error) {
 	face, err := opentype.NewFace(ft, &opentype.FaceOptions{
-		Size:    misakiFontSize,
+		Size:    float64(size),
 		DPI:     72,
 		Hinting: font.HintingFull,
 	})
@@ -64,7 +83,29 @@ func NewFace(name FontName) (*Face, error) {
 		return nil, fmt.Errorf("failed to create font face: %w", err)
 	}
 
-	return &Face{face: face, fontSize: misakiFontSize}, nil
+	return &Face{src: &outlineSource{face: face, font: ft}, fontSize: size}, nil
+}
+
+// HasGlyph reports whether the font or one of its fallbacks contains a
+// glyph for the given rune.
+func (f *Face) HasGlyph(r rune) bool {
+	_, ok := f.resolve(r)
+	return ok
+}
+
+// GlyphBitmap returns the untrimmed bitmap (as [][]bool) for the given rune,
+// sized to the glyph advance x font height.
+// true means the pixel is "on".
+func (f *Face) GlyphBitmap(r rune) [][]bool {
+	adv := f.Advance(r)
+	src, ok := f.resolve(r)
+	switch {
+	case !ok && f.replacement:
+		return replacementGlyph(f.fontSize, adv)
+	case src != f:
+		return fitCell(src.GlyphBitmap(r), f.fontSize, adv)
+	}
+	return f.src.draw(r, f.fontSize, adv)
 }
 
 // RuneBitmap returns a bitmap (as [][]bool) for the given rune.
@@ -73,37 +114,12 @@ func NewFace(name FontName) (*Face, error) {
 // true means the pixel is "on".
 func (f *Face) RuneBitmap(r rune) [][]bool {
 	adv := f.Advance(r)
-	metrics := f.face.Metrics()
-	ascent := metrics.Ascent.Ceil()
-
-	// Create image sized to the glyph advance x font height (8x8 fixed)
-	w := adv
-	if w < misakiFontSize {
-		w = misakiFontSize
-	}
-	img := image.NewGray(image.Rect(0, 0, w, misakiFontSize))
-	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
-
-	d := &font.Drawer{
-		Dst:  img,
-		Src:  image.Black,
-		Face: f.face,
-		Dot:  fixed.P(0, ascent),
-	}
-	d.DrawString(string(r))
-
-	// Build raw bitmap
-	raw := make([][]bool, misakiFontSize)
-	for y := 0; y < misakiFontSize; y++ {
-		raw[y] = make([]bool, adv)
-		for x := 0; x < adv; x++ {
-			raw[y][x] = img.GrayAt(x, y).Y < 128
-		}
-	}
+	raw := f.GlyphBitmap(r)
+	size := f.fontSize
 
 	// Find leftmost and rightmost non-empty columns
 	minX, maxX := adv, -1
-	for y := 0; y < misakiFontSize; y++ {
+	for y := 0; y < size; y++ {
 		for x := 0; x < adv; x++ {
 			if raw[y][x] {
 				if x < minX {
@@ -118,8 +134,8 @@ func (f *Face) RuneBitmap(r rune) [][]bool {
 
 	// If glyph is entirely blank, return a 1-cell blank column
 	if maxX < 0 {
-		blank := make([][]bool, misakiFontSize)
-		for y := 0; y < misakiFontSize; y++ {
+		blank := make([][]bool, size)
+		for y := 0; y < size; y++ {
 			blank[y] = make([]bool, 1)
 		}
 		return blank
@@ -129,8 +145,8 @@ func (f *Face) RuneBitmap(r rune) [][]bool {
 	// Adjacent glyphs each contribute 1 left pad → 2 spaces between chars
 	trimW := maxX - minX + 1
 	padW := trimW + 1 // +1 left only
-	bitmap := make([][]bool, misakiFontSize)
-	for y := 0; y < misakiFontSize; y++ {
+	bitmap := make([][]bool, size)
+	for y := 0; y < size; y++ {
 		bitmap[y] = make([]bool, padW)
 		for x := 0; x < trimW; x++ {
 			bitmap[y][x+1] = raw[y][minX+x]
@@ -141,9 +157,68 @@ func (f *Face) RuneBitmap(r rune) [][]bool {
 
 // Advance returns the horizontal advance width for the given rune in pixels.
 func (f *Face) Advance(r rune) int {
-	adv, ok := f.face.GlyphAdvance(r)
+	src, found := f.resolve(r)
+	if src != f {
+		num, den := f.fitScale(src)
+		return (src.Advance(r)*num + den/2) / den
+	}
+	if !found && f.replacement {
+		return f.fontSize
+	}
+
+	adv, ok := f.src.advance(r)
 	if !ok {
-		return misakiFontSize
+		return f.fontSize
+	}
+	return adv
+}
+
+// outlineSource draws glyphs of a TrueType/OpenType font.
+type outlineSource struct {
+	face font.Face
+	font *sfnt.Font
+}
+
+func (s *outlineSource) hasGlyph(r rune) bool {
+	idx, err := s.font.GlyphIndex(nil, r)
+	return err == nil && idx != 0
+}
+
+func (s *outlineSource) advance(r rune) (int, bool) {
+	adv, ok := s.face.GlyphAdvance(r)
+	if !ok {
+		return 0, false
+	}
+	return adv.Ceil(), true
+}
+
+func (s *outlineSource) draw(r rune, size, adv int) [][]bool {
+	metrics := s.face.Metrics()
+	ascent := metrics.Ascent.Ceil()
+
+	// Create image sized to the glyph advance x font height
+	w := adv
+	if w < size {
+		w = size
+	}
+	img := image.NewGray(image.Rect(0, 0, w, size))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.Black,
+		Face: s.face,
+		Dot:  fixed.P(0, ascent),
+	}
+	d.DrawString(string(r))
+
+	// Build raw bitmap
+	raw := make([][]bool, size)
+	for y := 0; y < size; y++ {
+		raw[y] = make([]bool, adv)
+		for x := 0; x < adv; x++ {
+			raw[y][x] = img.GrayAt(x, y).Y < 128
+		}
 	}
-	return adv.Ceil()
+	return raw
 }
@@ -118,3 +118,32 @@ func TestAdvance(t *testing.T) {
 		t.Errorf("Advance('あ') = %d, want %d", advKana, misakiFontSize)
 	}
 }
+
+func TestHasGlyph(t *testing.T) {
+	face, err := NewFace(FontMisakiGothic2nd)
+	if err != nil {
+		t.Fatalf("NewFace failed: %v", err)
+	}
+
+	if !face.HasGlyph('あ') {
+		t.Error("HasGlyph('あ') = false, want true")
+	}
+	if face.HasGlyph('😀') {
+		t.Error("HasGlyph('😀') = true, want false")
+	}
+}
+
+func TestGlyphBitmap_Untrimmed(t *testing.T) {
+	face, err := NewFace(FontMisakiGothic2nd)
+	if err != nil {
+		t.Fatalf("NewFace failed: %v", err)
+	}
+
+	bm := face.GlyphBitmap('あ')
+	if len(bm) != misakiFontSize {
+		t.Fatalf("GlyphBitmap('あ') height = %d, want %d", len(bm), misakiFontSize)
+	}
+	if len(bm[0]) != face.Advance('あ') {
+		t.Errorf("GlyphBitmap('あ') width = %d, want advance %d", len(bm[0]), face.Advance('あ'))
+	}
+}
@@ -0,0 +1,361 @@
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// pcfMagic is the signature at the start of a PCF file.
+const pcfMagic = "\x01fcp"
+
+// PCF table types.
+const (
+	pcfTableProperties      = 1 << 0
+	pcfTableAccelerators    = 1 << 1
+	pcfTableMetrics         = 1 << 2
+	pcfTableBitmaps         = 1 << 3
+	pcfTableBDFEncodings    = 1 << 5
+	pcfTableBDFAccelerators = 1 << 8
+)
+
+// PCF table format flags.
+const (
+	pcfGlyphPadMask     = 3 << 0 // bitmap rows are padded to 1 << n bytes
+	pcfByteMask         = 1 << 2 // big-endian byte order
+	pcfBitMask          = 1 << 3 // most significant bit first
+	pcfScanUnitMask     = 3 << 4 // bitmap bytes are swapped in units of 1 << n
+	pcfCompressedMetric = 0x100  // metrics are stored as bytes
+)
+
+// pcfReader reads the tables of a Portable Compiled Format font.
+type pcfReader struct {
+	data   []byte
+	tables map[uint32][2]uint32 // type -> offset, size
+}
+
+// pcfTable is a cursor over a single PCF table.
+type pcfTable struct {
+	data   []byte
+	pos    int
+	format uint32
+	order  binary.ByteOrder
+	err    error
+}
+
+func (t *pcfTable) bytes(n int) []byte {
+	if t.err != nil {
+		return nil
+	}
+	if n < 0 || t.pos+n > len(t.data) {
+		t.err = fmt.Errorf("invalid PCF: table truncated")
+		return nil
+	}
+	b := t.data[t.pos : t.pos+n]
+	t.pos += n
+	return b
+}
+
+func (t *pcfTable) u8() int {
+	b := t.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return int(b[0])
+}
+
+func (t *pcfTable) i16() int {
+	b := t.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return int(int16(t.order.Uint16(b)))
+}
+
+func (t *pcfTable) u16() int {
+	b := t.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return int(t.order.Uint16(b))
+}
+
+func (t *pcfTable) i32() int {
+	b := t.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return int(int32(t.order.Uint32(b)))
+}
+
+// table returns a cursor at the start of the table of the given type,
+// positioned after its format field. It returns nil if the table is absent.
+func (r *pcfReader) table(typ uint32) (*pcfTable, error) {
+	loc, ok := r.tables[typ]
+	if !ok {
+		return nil, nil
+	}
+	off, size := loc[0], loc[1]
+	if uint64(off)+uint64(size) > uint64(len(r.data)) || size < 4 {
+		return nil, fmt.Errorf("invalid PCF: table %#x out of range", typ)
+	}
+	data := r.data[off : off+size]
+	format := binary.LittleEndian.Uint32(data)
+	t := &pcfTable{data: data, pos: 4, format: format, order: binary.LittleEndian}
+	if format&pcfByteMask != 0 {
+		t.order = binary.BigEndian
+	}
+	return t, nil
+}
+
+// pcfMetric holds the metrics of one PCF glyph.
+type pcfMetric struct {
+	left, right, width, ascent, descent int
+}
+
+// parsePCF parses a font in the X11 Portable Compiled Format.
+func parsePCF(data []byte) (*Face, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("invalid PCF: file truncated")
+	}
+	count := binary.LittleEndian.Uint32(data[4:])
+	if 8+uint64(count)*16 > uint64(len(data)) {
+		return nil, fmt.Errorf("invalid PCF: table of contents truncated")
+	}
+	r := &pcfReader{data: data, tables: make(map[uint32][2]uint32)}
+	for i := uint32(0); i < count; i++ {
+		e := data[8+i*16:]
+		typ := binary.LittleEndian.Uint32(e)
+		r.tables[typ] = [2]uint32{binary.LittleEndian.Uint32(e[12:]), binary.LittleEndian.Uint32(e[8:])}
+	}
+
+	registry, encoding, err := pcfCharset(r)
+	if err != nil {
+		return nil, err
+	}
+	ascent, descent, err := pcfFontMetrics(r)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := pcfMetrics(r)
+	if err != nil {
+		return nil, err
+	}
+	bitmaps, err := pcfBitmaps(r, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := r.table(pcfTableBDFEncodings)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("invalid PCF: missing encodings table")
+	}
+	min2, max2 := t.i16(), t.i16()
+	min1, max1 := t.i16(), t.i16()
+	t.i16() // default char
+	glyphs := make(map[rune]*bitmapGlyph)
+	for b1 := min1; b1 <= max1; b1++ {
+		for b2 := min2; b2 <= max2; b2++ {
+			idx := t.u16()
+			if t.err != nil {
+				return nil, t.err
+			}
+			if idx == 0xFFFF || idx >= len(metrics) {
+				continue
+			}
+			ch, ok := charsetRune(registry, encoding, b1<<8|b2)
+			if !ok {
+				continue
+			}
+			m := metrics[idx]
+			glyphs[ch] = &bitmapGlyph{
+				advance: max(m.width, 0), // negative widths would make invalid bitmaps
+				left:    m.left,
+				top:     m.ascent,
+				bits:    bitmaps[idx],
+			}
+		}
+	}
+	if len(glyphs) == 0 {
+		return nil, fmt.Errorf("invalid PCF: no glyphs")
+	}
+	return newBitmapFace(glyphs, ascent, descent), nil
+}
+
+// pcfCharset reads CHARSET_REGISTRY and CHARSET_ENCODING from the properties table.
+func pcfCharset(r *pcfReader) (string, string, error) {
+	t, err := r.table(pcfTableProperties)
+	if err != nil || t == nil {
+		return "", "", err
+	}
+	n := t.i32()
+	type prop struct {
+		name, value int
+		isString    bool
+	}
+	var props []prop
+	for i := 0; i < n && t.err == nil; i++ {
+		name := t.i32()
+		isString := t.u8() != 0
+		value := t.i32()
+		props = append(props, prop{name: name, value: value, isString: isString})
+	}
+	if n&3 != 0 {
+		t.bytes(4 - n&3)
+	}
+	strs := t.bytes(t.i32())
+	if t.err != nil {
+		return "", "", t.err
+	}
+	str := func(off int) string {
+		if off < 0 || off >= len(strs) {
+			return ""
+		}
+		end := off
+		for end < len(strs) && strs[end] != 0 {
+			end++
+		}
+		return string(strs[off:end])
+	}
+
+	var registry, encoding string
+	for _, p := range props {
+		if !p.isString {
+			continue
+		}
+		switch str(p.name) {
+		case "CHARSET_REGISTRY":
+			registry = str(p.value)
+		case "CHARSET_ENCODING":
+			encoding = str(p.value)
+		}
+	}
+	return registry, encoding, nil
+}
+
+// pcfFontMetrics reads the font ascent and descent from the accelerators table.
+func pcfFontMetrics(r *pcfReader) (int, int, error) {
+	t, err := r.table(pcfTableBDFAccelerators)
+	if err != nil {
+		return 0, 0, err
+	}
+	if t == nil {
+		if t, err = r.table(pcfTableAccelerators); err != nil {
+			return 0, 0, err
+		}
+	}
+	if t == nil {
+		return 0, 0, fmt.Errorf("invalid PCF: missing accelerators table")
+	}
+	t.bytes(8) // flags
+	ascent, descent := t.i32(), t.i32()
+	if t.err != nil {
+		return 0, 0, t.err
+	}
+	if ascent+descent <= 0 {
+		return 0, 0, fmt.Errorf("invalid PCF: cannot determine the glyph cell height")
+	}
+	return ascent, descent, nil
+}
+
+// pcfMetrics reads the metrics of every glyph.
+func pcfMetrics(r *pcfReader) ([]pcfMetric, error) {
+	t, err := r.table(pcfTableMetrics)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("invalid PCF: missing metrics table")
+	}
+	var metrics []pcfMetric
+	if t.format&pcfCompressedMetric != 0 {
+		n := t.i16()
+		for i := 0; i < n && t.err == nil; i++ {
+			metrics = append(metrics, pcfMetric{
+				left:    t.u8() - 0x80,
+				right:   t.u8() - 0x80,
+				width:   t.u8() - 0x80,
+				ascent:  t.u8() - 0x80,
+				descent: t.u8() - 0x80,
+			})
+		}
+	} else {
+		n := t.i32()
+		for i := 0; i < n && t.err == nil; i++ {
+			m := pcfMetric{left: t.i16(), right: t.i16(), width: t.i16(), ascent: t.i16(), descent: t.i16()}
+			t.i16() // attributes
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics, t.err
+}
+
+// pcfBitmaps reads the bitmap of every glyph.
+func pcfBitmaps(r *pcfReader, metrics []pcfMetric) ([][][]bool, error) {
+	t, err := r.table(pcfTableBitmaps)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("invalid PCF: missing bitmaps table")
+	}
+	n := t.i32()
+	if n != len(metrics) {
+		return nil, fmt.Errorf("invalid PCF: %d bitmaps for %d glyphs", n, len(metrics))
+	}
+	offsets := make([]int, n)
+	for i := range offsets {
+		offsets[i] = t.i32()
+	}
+	var sizes [4]int
+	for i := range sizes {
+		sizes[i] = t.i32()
+	}
+	pad := int(t.format & pcfGlyphPadMask)
+	data := t.bytes(sizes[pad])
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	padBytes := 1 << pad
+	scanUnit := 1 << ((t.format & pcfScanUnitMask) >> 4)
+	msbFirst := t.format&pcfBitMask != 0
+	// Bytes are swapped within each scan unit when byte and bit order differ
+	swap := scanUnit > 1 && (t.format&pcfByteMask != 0) != msbFirst
+
+	bitmaps := make([][][]bool, n)
+	for i, m := range metrics {
+		w, h := m.right-m.left, m.ascent+m.descent
+		if w <= 0 || h <= 0 {
+			continue
+		}
+		stride := (w + padBytes*8 - 1) / (padBytes * 8) * padBytes
+		off := offsets[i]
+		if off < 0 || off+stride*h > len(data) {
+			return nil, fmt.Errorf("invalid PCF: bitmap %d out of range", i)
+		}
+		rows := make([][]bool, h)
+		for y := range rows {
+			rows[y] = make([]bool, w)
+			line := data[off+y*stride : off+(y+1)*stride]
+			for x := 0; x < w; x++ {
+				bi := x / 8
+				if swap {
+					bi = bi/scanUnit*scanUnit + scanUnit - 1 - bi%scanUnit
+				}
+				if bi >= len(line) {
+					continue
+				}
+				bit := uint(x % 8)
+				if msbFirst {
+					bit = 7 - bit
+				}
+				rows[y][x] = line[bi]&(1<<bit) != 0
+			}
+		}
+		bitmaps[i] = rows
+	}
+	return bitmaps, nil
+}
@@ -0,0 +1,150 @@
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// buildTestPCF builds a PCF font with a single 3x5 'A' glyph, using the
+// given byte/bit order and glyph padding for the bitmaps table.
+func buildTestPCF(t *testing.T, format uint32) []byte {
+	t.Helper()
+	order := binary.ByteOrder(binary.LittleEndian)
+	if format&pcfByteMask != 0 {
+		order = binary.BigEndian
+	}
+	table := func(format uint32, write func(b *bytes.Buffer)) []byte {
+		var b bytes.Buffer
+		binary.Write(&b, binary.LittleEndian, format)
+		write(&b)
+		return b.Bytes()
+	}
+
+	accel := table(0, func(b *bytes.Buffer) {
+		b.Write(make([]byte, 8))
+		binary.Write(b, binary.LittleEndian, int32(5)) // ascent
+		binary.Write(b, binary.LittleEndian, int32(1)) // descent
+	})
+	metrics := table(pcfCompressedMetric, func(b *bytes.Buffer) {
+		binary.Write(b, binary.LittleEndian, int16(1))
+		b.Write([]byte{0x80 + 0, 0x80 + 3, 0x80 + 4, 0x80 + 5, 0x80 + 0})
+	})
+	rows := []byte{0x40, 0xA0, 0xE0, 0xA0, 0xA0} // MSB first
+	pad := 1 << (format & pcfGlyphPadMask)
+	bitmaps := table(format, func(b *bytes.Buffer) {
+		binary.Write(b, order, int32(1))
+		binary.Write(b, order, int32(0))
+		var data []byte
+		for _, r := range rows {
+			if format&pcfBitMask == 0 {
+				r = reverseBits(r)
+			}
+			row := make([]byte, pad)
+			row[0] = r
+			data = append(data, row...)
+		}
+		for i := 0; i < 4; i++ {
+			binary.Write(b, order, int32(len(data)))
+		}
+		b.Write(data)
+	})
+	encodings := table(0, func(b *bytes.Buffer) {
+		for _, v := range []int16{'A', 'A', 0, 0, 0} {
+			binary.Write(b, binary.LittleEndian, v)
+		}
+		binary.Write(b, binary.LittleEndian, uint16(0))
+	})
+
+	tables := []struct {
+		typ  uint32
+		data []byte
+	}{
+		{pcfTableAccelerators, accel},
+		{pcfTableMetrics, metrics},
+		{pcfTableBitmaps, bitmaps},
+		{pcfTableBDFEncodings, encodings},
+	}
+	var out bytes.Buffer
+	out.WriteString(pcfMagic)
+	binary.Write(&out, binary.LittleEndian, uint32(len(tables)))
+	offset := 8 + 16*len(tables)
+	for _, tb := range tables {
+		binary.Write(&out, binary.LittleEndian, []uint32{tb.typ, 0, uint32(len(tb.data)), uint32(offset)})
+		offset += len(tb.data)
+	}
+	for _, tb := range tables {
+		out.Write(tb.data)
+	}
+	return out.Bytes()
+}
+
+func reverseBits(b byte) byte {
+	var r byte
+	for i := 0; i < 8; i++ {
+		r = r<<1 | b>>i&1
+	}
+	return r
+}
+
+func TestParsePCF(t *testing.T) {
+	formats := map[string]uint32{
+		"msb":       pcfByteMask | pcfBitMask,
+		"lsb":       0,
+		"msb-pad32": pcfByteMask | pcfBitMask | 2,
+	}
+	want := []string{
+		".#..",
+		"#.#.",
+		"###.",
+		"#.#.",
+		"#.#.",
+		"....",
+	}
+	for name, format := range formats {
+		t.Run(name, func(t *testing.T) {
+			face, err := ParseFace(buildTestPCF(t, format))
+			if err != nil {
+				t.Fatalf("ParseFace failed: %v", err)
+			}
+			if face.FontSize() != 6 {
+				t.Errorf("FontSize() = %d, want 6", face.FontSize())
+			}
+			bm := face.GlyphBitmap('A')
+			for y, row := range want {
+				for x, c := range row {
+					if bm[y][x] != (c == '#') {
+						t.Fatalf("GlyphBitmap('A')[%d][%d] = %v, want %v", y, x, bm[y][x], c == '#')
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParsePCF_Truncated(t *testing.T) {
+	data := buildTestPCF(t, 0)
+	if _, err := ParseFace(data[:len(data)-8]); err == nil {
+		t.Error("ParseFace(truncated PCF) expected error, got nil")
+	}
+}
+
+func TestParsePCF_NegativeAdvance(t *testing.T) {
+	data := buildTestPCF(t, 0)
+	// Width byte of the compressed metric: 0x80+4 becomes 0x80-4
+	metric := []byte{0x80 + 0, 0x80 + 3, 0x80 + 4, 0x80 + 5, 0x80 + 0}
+	i := bytes.Index(data, metric)
+	if i < 0 {
+		t.Fatal("compressed metric not found")
+	}
+	data[i+2] = 0x80 - 4
+
+	face, err := ParseFace(data)
+	if err != nil {
+		t.Fatalf("ParseFace failed: %v", err)
+	}
+	if face.Advance('A') != 0 {
+		t.Errorf("Advance('A') = %d, want a negative width clamped to 0", face.Advance('A'))
+	}
+	face.RuneBitmap('A')
+}
@@ -0,0 +1,56 @@
+// Package input reads the text of banners from files and streams.
+package input
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadText reads all of r as banner text. CRLF line endings become LF and
+// the final line ending is dropped.
+func ReadText(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return strings.TrimSuffix(text, "\n"), nil
+}
+
+// ReadFile reads the banner text of the file at path like ReadText,
+// or of stdin if path is empty.
+func ReadFile(path string) (string, error) {
+	if path == "" {
+		return ReadText(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return ReadText(f)
+}
+
+// StreamLines renders every non-empty line read from r as soon as it is
+// complete, including a last line without a line ending, and writes it to
+// w followed by a newline with a single write, so each banner shows up
+// without waiting for the next line.
+func StreamLines(r io.Reader, w io.Writer, render func(line string) string) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			if _, werr := io.WriteString(w, render(line)+"\n"); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
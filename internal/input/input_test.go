@@ -0,0 +1,106 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"あ\r\nい\r\n", "あ\nい"},
+		{"あ\nい", "あ\nい"},
+		{"あ\n\n", "あ\n"}, // only the final line ending is dropped
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := ReadText(strings.NewReader(tt.in))
+		if err != nil || got != tt.want {
+			t.Errorf("ReadText(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "text.txt")
+	if err := os.WriteFile(path, []byte("あ\r\nい\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadFile(path); err != nil || got != "あ\nい" {
+		t.Errorf("ReadFile() = %q, %v, want %q", got, err, "あ\nい")
+	}
+	if _, err := ReadFile(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ReadFile(missing) = %v, want ErrNotExist", err)
+	}
+}
+
+// writeRecorder records every Write call.
+type writeRecorder struct {
+	writes []string
+}
+
+func (w *writeRecorder) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestStreamLines(t *testing.T) {
+	var w writeRecorder
+	in := "a\r\n\nb\n\r\nc" // CRLF, empty lines and a last line without \n
+	err := StreamLines(strings.NewReader(in), &w, func(line string) string {
+		return "[" + line + "]"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"[a]\n", "[b]\n", "[c]\n"}
+	if !slices.Equal(w.writes, want) {
+		t.Errorf("writes = %q, want one write per non-empty line %q", w.writes, want)
+	}
+}
+
+func TestStreamLines_AsLinesArrive(t *testing.T) {
+	pr, pw := io.Pipe()
+	var w writeRecorder
+	done := make(chan error)
+	rendered := make(chan string)
+	go func() {
+		done <- StreamLines(pr, &w, func(line string) string {
+			rendered <- line
+			return line
+		})
+	}()
+
+	// The first line is rendered before the second one is written
+	io.WriteString(pw, "a\n")
+	if got := <-rendered; got != "a" {
+		t.Errorf("rendered %q, want a", got)
+	}
+	io.WriteString(pw, "b\n")
+	if got := <-rendered; got != "b" {
+		t.Errorf("rendered %q, want b", got)
+	}
+	pw.Close()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("closed")
+}
+
+func TestStreamLines_WriteError(t *testing.T) {
+	err := StreamLines(strings.NewReader("a\nb\n"), failingWriter{}, func(line string) string { return line })
+	if err == nil {
+		t.Error("StreamLines() = nil, want the write error")
+	}
+}